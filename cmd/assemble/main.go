@@ -77,10 +77,14 @@ type JusticeDirection struct {
 	Directions map[scotusdb.Direction]int
 }
 
-func getAllDirections(terms []*scotusdb.Term) []*JusticeDirection {
+func getAllDirections(
+	ctx context.Context,
+	dataDir string,
+) ([]*JusticeDirection, error) {
 	byJustice := map[string]*JusticeDirection{}
-	for _, t := range terms {
-		for _, c := range t.Cases {
+	if err := scotusdb.ReadEach(
+		ctx,
+		func(year int, c *scotusdb.Case) error {
 			for _, v := range c.Votes {
 				forJustice := byJustice[v.JusticeName]
 				if forJustice == nil {
@@ -92,7 +96,11 @@ func getAllDirections(terms []*scotusdb.Term) []*JusticeDirection {
 				}
 				forJustice.Directions[v.Direction]++
 			}
-		}
+			return nil
+		},
+		scotusdb.WithDataDir(dataDir),
+	); err != nil {
+		return nil, err
 	}
 
 	var directions []*JusticeDirection
@@ -104,7 +112,7 @@ func getAllDirections(terms []*scotusdb.Term) []*JusticeDirection {
 		return directions[i].Justice < directions[j].Justice
 	})
 
-	return directions
+	return directions, nil
 }
 
 func main() {
@@ -1,64 +1,486 @@
 package main
 
 import (
-	"encoding/csv"
+	"archive/zip"
+	"bufio"
 	"encoding/json"
+	"errors"
 	"flag"
+	"fmt"
 	"io"
 	"log"
+	"math"
 	"os"
+	"strconv"
+	"strings"
+
+	"github.com/kellegous/scotus/pkg/csv"
+)
+
+type Flags struct {
+	TSV     bool
+	NDJSON  bool
+	Typed   bool
+	Columns string
+	Schema  string
+}
+
+func (f *Flags) Register(fs *flag.FlagSet) {
+	fs.BoolVar(
+		&f.TSV,
+		"tsv",
+		false,
+		"whether the input is tab separated")
+	fs.BoolVar(
+		&f.NDJSON,
+		"ndjson",
+		false,
+		"emit a JSON object per line instead of an array")
+	fs.BoolVar(
+		&f.Typed,
+		"typed",
+		false,
+		"infer int, float and bool columns and emit blank values as null")
+	fs.StringVar(
+		&f.Columns,
+		"columns",
+		"",
+		"comma separated columns to include (default all), where blank headers are named like column3 and repeats like id_2")
+	fs.StringVar(
+		&f.Schema,
+		"schema",
+		"",
+		"a file where a JSON Schema describing the rows will be written")
+}
+
+func (f *Flags) csvOptions() []csv.Option {
+	opts := []csv.Option{csv.StripBOM()}
+	if f.TSV {
+		opts = append(opts, csv.WithDelimiter('\t'))
+	}
+	return opts
+}
+
+// Kind is the inferred type of a column. Kinds are ordered so that a
+// column's kind only ever widens as more values are seen.
+type Kind int
+
+const (
+	KindNull Kind = iota
+	KindBool
+	KindInt
+	KindFloat
+	KindString
 )
 
-func toJSON(r io.Reader) ([]map[string]string, error) {
-	cr := csv.NewReader(r)
+func (k Kind) schemaType() string {
+	switch k {
+	case KindNull:
+		return "null"
+	case KindBool:
+		return "boolean"
+	case KindInt:
+		return "integer"
+	case KindFloat:
+		return "number"
+	}
+	return "string"
+}
+
+// hasLeadingZero reports whether s is a number written with a leading
+// zero, like "007". These are usually codes, which would be mangled if
+// they were emitted as numbers.
+func hasLeadingZero(s string) bool {
+	s = strings.TrimLeft(s, "+-")
+	return len(s) > 1 && s[0] == '0' && s[1] != '.'
+}
 
-	// read the fields from the header
-	fields, err := cr.Read()
+func kindOf(s string) Kind {
+	if s == "" {
+		return KindNull
+	}
+	if hasLeadingZero(s) {
+		return KindString
+	}
+	if _, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return KindInt
+	}
+	// NaN and Inf parse as floats, but they can't be represented in JSON.
+	if f, err := strconv.ParseFloat(s, 64); err == nil && !math.IsNaN(f) && !math.IsInf(f, 0) {
+		return KindFloat
+	}
+	if s == "true" || s == "false" {
+		return KindBool
+	}
+	return KindString
+}
+
+// widen finds the narrowest kind that can represent both a and b.
+func widen(a, b Kind) Kind {
+	if a == KindNull {
+		return b
+	} else if b == KindNull || a == b {
+		return a
+	}
+
+	// ints and floats are the only kinds that mix.
+	if (a == KindInt && b == KindFloat) || (a == KindFloat && b == KindInt) {
+		return KindFloat
+	}
+
+	return KindString
+}
+
+type Column struct {
+	Name     string
+	Index    int
+	Kind     Kind
+	Nullable bool
+}
+
+func (c *Column) observe(s string) {
+	k := kindOf(s)
+	if k == KindNull {
+		c.Nullable = true
+	}
+	c.Kind = widen(c.Kind, k)
+}
+
+// value converts s to a JSON value according to the column's kind.
+func (c *Column) value(s string) interface{} {
+	// Nullable is only ever set when types are being inferred.
+	if s == "" && c.Nullable {
+		return nil
+	}
+
+	switch c.Kind {
+	case KindNull:
+		return nil
+	case KindBool:
+		return s == "true"
+	case KindInt:
+		i, _ := strconv.ParseInt(s, 10, 64)
+		return i
+	case KindFloat:
+		f, _ := strconv.ParseFloat(s, 64)
+		return f
+	}
+	return s
+}
+
+// open opens src for reading. If src is a zip archive, the single file
+// within it is opened instead.
+func open(src string) (io.ReadCloser, error) {
+	if !strings.HasSuffix(strings.ToLower(src), ".zip") {
+		return os.Open(src)
+	}
+
+	zr, err := zip.OpenReader(src)
+	if err != nil {
+		return nil, err
+	}
+
+	if n := len(zr.File); n != 1 {
+		zr.Close()
+		return nil, fmt.Errorf("expected a single file but there are %d", n)
+	}
+
+	r, err := zr.File[0].Open()
 	if err != nil {
+		zr.Close()
 		return nil, err
 	}
 
-	rows := []map[string]string{}
+	return &zipFile{ReadCloser: r, zr: zr}, nil
+}
+
+type zipFile struct {
+	io.ReadCloser
+	zr *zip.ReadCloser
+}
+
+func (f *zipFile) Close() error {
+	f.ReadCloser.Close()
+	return f.zr.Close()
+}
+
+// uniqueNames gives every column a distinct, non-blank name so that the
+// emitted objects never have duplicate keys. Blank headers are named for
+// their position, e.g. column3, and repeated ones get a suffix, e.g. id_2.
+func uniqueNames(all []string) []string {
+	used := map[string]bool{}
+	for _, name := range all {
+		used[name] = true
+	}
+
+	seen := map[string]bool{}
+	names := make([]string, len(all))
+	for i, name := range all {
+		if name == "" {
+			name = fmt.Sprintf("column%d", i+1)
+		}
+
+		if seen[name] {
+			base := name
+			for n := 2; seen[name] || used[name]; n++ {
+				name = fmt.Sprintf("%s_%d", base, n)
+			}
+		}
+
+		seen[name] = true
+		names[i] = name
+	}
+	return names
+}
+
+// selectColumns finds the columns that will be emitted. Columns are
+// identified by their position, since headers may be blank or repeated.
+func selectColumns(
+	all []string,
+	projection string,
+	typed bool,
+) ([]*Column, error) {
+	unique := uniqueNames(all)
+
+	index := map[string]int{}
+	for i, name := range unique {
+		index[name] = i
+	}
+
+	names := unique
+	if projection != "" {
+		names = strings.Split(projection, ",")
+	}
+
+	cols := make([]*Column, 0, len(names))
+	for _, name := range names {
+		i, ok := index[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown column: %s", name)
+		}
+
+		kind := KindString
+		if typed {
+			kind = KindNull
+		}
+
+		cols = append(cols, &Column{
+			Name:  name,
+			Index: i,
+			Kind:  kind,
+		})
+	}
+
+	return cols, nil
+}
+
+// errDone can be returned by the function given to eachRow to stop reading
+// rows early.
+var errDone = errors.New("done")
+
+// eachRow calls fn with nil values once the header has been read and then
+// with the values of every row in src.
+func eachRow(
+	src string,
+	opts []csv.Option,
+	fn func(cr *csv.Reader, vals []string) error,
+) error {
+	r, err := open(src)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	cr, err := csv.NewReader(r, opts...)
+	if err != nil {
+		return err
+	}
+
+	if err := fn(cr, nil); err == errDone {
+		return nil
+	} else if err != nil {
+		return err
+	}
 
 	for {
-		row, err := cr.Read()
+		row, err := cr.Next()
 		if err == io.EOF {
-			break
+			return nil
 		} else if err != nil {
-			return nil, err
+			return err
 		}
 
-		obj := map[string]string{}
+		if err := fn(cr, row.Values()); err == errDone {
+			return nil
+		} else if err != nil {
+			return err
+		}
+	}
+}
 
-		for i, field := range fields {
-			obj[field] = row[i]
+// inferColumns reads all of src to find the columns and their kinds, so
+// that every value in a column is emitted with the same type.
+func inferColumns(src string, flags *Flags) ([]*Column, error) {
+	var cols []*Column
+	if err := eachRow(
+		src,
+		flags.csvOptions(),
+		func(cr *csv.Reader, vals []string) error {
+			if vals == nil {
+				var err error
+				cols, err = selectColumns(cr.Columns(), flags.Columns, flags.Typed)
+				if err == nil && !flags.Typed {
+					return errDone
+				}
+				return err
+			}
+
+			for _, col := range cols {
+				col.observe(vals[col.Index])
+			}
+			return nil
+		},
+	); err != nil {
+		return nil, err
+	}
+
+	return cols, nil
+}
+
+func writeRow(
+	w *bufio.Writer,
+	cols []*Column,
+	vals []string,
+) error {
+	w.WriteByte('{')
+	for i, col := range cols {
+		if i > 0 {
+			w.WriteByte(',')
+		}
+
+		k, err := json.Marshal(col.Name)
+		if err != nil {
+			return err
+		}
+		w.Write(k)
+		w.WriteByte(':')
+
+		v, err := json.Marshal(col.value(vals[col.Index]))
+		if err != nil {
+			return err
+		}
+		w.Write(v)
+	}
+	return w.WriteByte('}')
+}
+
+func toJSON(
+	w io.Writer,
+	src string,
+	cols []*Column,
+	flags *Flags,
+) error {
+	bw := bufio.NewWriter(w)
+
+	n := 0
+	if err := eachRow(
+		src,
+		flags.csvOptions(),
+		func(cr *csv.Reader, vals []string) error {
+			if vals == nil {
+				return nil
+			}
+
+			if flags.NDJSON {
+				if err := writeRow(bw, cols, vals); err != nil {
+					return err
+				}
+				return bw.WriteByte('\n')
+			}
+
+			sep := byte(',')
+			if n == 0 {
+				sep = '['
+			}
+			n++
+
+			if err := bw.WriteByte(sep); err != nil {
+				return err
+			}
+			return writeRow(bw, cols, vals)
+		},
+	); err != nil {
+		return err
+	}
+
+	if !flags.NDJSON {
+		if n == 0 {
+			bw.WriteByte('[')
 		}
+		bw.WriteString("]\n")
+	}
+
+	return bw.Flush()
+}
+
+func writeSchema(dst string, cols []*Column) error {
+	props := map[string]interface{}{}
+	required := make([]string, 0, len(cols))
+	for _, col := range cols {
+		var typ interface{} = col.Kind.schemaType()
+		if col.Nullable && col.Kind != KindNull {
+			typ = []string{col.Kind.schemaType(), "null"}
+		}
+		props[col.Name] = map[string]interface{}{
+			"type": typ,
+		}
+		required = append(required, col.Name)
+	}
 
-		rows = append(rows, obj)
+	w, err := os.Create(dst)
+	if err != nil {
+		return err
 	}
+	defer w.Close()
 
-	return rows, nil
+	e := json.NewEncoder(w)
+	e.SetIndent("", "  ")
+	if err := e.Encode(map[string]interface{}{
+		"$schema":              "https://json-schema.org/draft/2020-12/schema",
+		"type":                 "object",
+		"properties":           props,
+		"required":             required,
+		"additionalProperties": false,
+	}); err != nil {
+		return err
+	}
+
+	return w.Close()
 }
 
 func main() {
+	var flags Flags
+	flags.Register(flag.CommandLine)
 	flag.Parse()
 
 	if flag.NArg() != 1 {
-		log.Panic("wrong use")
+		log.Panic("usage: csvtojson [flags] file.csv|file.tsv|file.zip")
 	}
 
-	r, err := os.Open(flag.Arg(0))
+	src := flag.Arg(0)
+
+	cols, err := inferColumns(src, &flags)
 	if err != nil {
 		log.Panic(err)
 	}
-	defer r.Close()
 
-	data, err := toJSON(r)
-	if err != nil {
-		log.Panic(err)
+	if flags.Schema != "" {
+		if err := writeSchema(flags.Schema, cols); err != nil {
+			log.Panic(err)
+		}
 	}
 
-	if err := json.NewEncoder(os.Stdout).Encode(data); err != nil {
+	if err := toJSON(os.Stdout, src, cols, &flags); err != nil {
 		log.Panic(err)
 	}
 }
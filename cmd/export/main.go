@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kellegous/scotus/pkg/csv"
+	"github.com/kellegous/scotus/pkg/data"
+)
+
+type Flags struct {
+	DataDir string
+	OutDir  string
+	Columns string
+}
+
+func (f *Flags) Register(fs *flag.FlagSet) {
+	fs.StringVar(
+		&f.DataDir,
+		"data-dir",
+		"data",
+		"the directory where the data is stashed")
+	fs.StringVar(
+		&f.OutDir,
+		"out-dir",
+		"export",
+		"the directory where the CSV files will be written")
+	fs.StringVar(
+		&f.Columns,
+		"columns",
+		"",
+		"comma separated columns to export, only valid with a single table")
+}
+
+func exportTable(
+	m *data.Model,
+	dst string,
+	table string,
+	opts ...csv.Option,
+) error {
+	w, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	defer w.Close()
+
+	if err := m.WriteCSV(w, table, opts...); err != nil {
+		return err
+	}
+
+	return w.Close()
+}
+
+func main() {
+	var flags Flags
+	flags.Register(flag.CommandLine)
+	flag.Parse()
+
+	tables := flag.Args()
+	if len(tables) == 0 {
+		tables = data.Tables
+	}
+
+	var opts []csv.Option
+	if flags.Columns != "" {
+		if len(tables) != 1 {
+			log.Panic("-columns requires a single table")
+		}
+		opts = append(opts, csv.WithColumns(strings.Split(flags.Columns, ",")...))
+	}
+
+	if err := data.EnsureDir(
+		flags.DataDir,
+		0755,
+		false,
+	); err != nil {
+		log.Panic(err)
+	}
+
+	if err := data.EnsureDir(
+		flags.OutDir,
+		0755,
+		false,
+	); err != nil {
+		log.Panic(err)
+	}
+
+	m, err := data.LoadModel(context.Background(), flags.DataDir)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	for _, table := range tables {
+		if err := exportTable(
+			m,
+			filepath.Join(flags.OutDir, table+".csv"),
+			table,
+			opts...,
+		); err != nil {
+			log.Panicf("%s: %s", table, err)
+		}
+	}
+}
@@ -1,7 +1,6 @@
 package main
 
 import (
-	"encoding/csv"
 	"encoding/json"
 	"flag"
 	"fmt"
@@ -12,6 +11,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/kellegous/scotus/pkg/csv"
 	"github.com/kellegous/scotus/pkg/data/scotusdb"
 )
 
@@ -103,25 +103,24 @@ func parseCase(
 }
 
 func readCases(r io.Reader) ([]*scotusdb.Case, error) {
-	cr := csv.NewReader(r)
-	cr.Comma = '\t'
-
-	if _, err := cr.Read(); err == io.EOF {
-		return nil, io.ErrUnexpectedEOF
-	} else if err != nil {
+	cr, err := csv.NewReader(
+		r,
+		csv.WithDelimiter('\t'),
+		csv.StripBOM())
+	if err != nil {
 		return nil, err
 	}
 
 	var cases []*scotusdb.Case
 	for i := 1; ; i++ {
-		row, err := cr.Read()
+		row, err := cr.Next()
 		if err == io.EOF {
 			break
 		} else if err != nil {
 			return nil, err
 		}
 
-		c, err := parseCase(fmt.Sprintf("2021-%03d", i), row)
+		c, err := parseCase(fmt.Sprintf("2021-%03d", i), row.Values())
 		if err != nil {
 			return nil, err
 		}
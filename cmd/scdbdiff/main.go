@@ -0,0 +1,318 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/kellegous/scotus/pkg/data/scotusdb"
+)
+
+type Flags struct {
+	CacheDir    string
+	Format      string
+	OldEncoding string
+	NewEncoding string
+}
+
+func (f *Flags) Register(fs *flag.FlagSet) {
+	fs.StringVar(
+		&f.CacheDir,
+		"cache-dir",
+		scotusdb.DefaultCacheDir(),
+		"the directory where downloaded releases will be kept")
+	fs.StringVar(
+		&f.Format,
+		"format",
+		"text",
+		"the output format (text or json)")
+	fs.StringVar(
+		&f.OldEncoding,
+		"old-encoding",
+		scotusdb.Latin1.String(),
+		"the encoding of the old release (latin1 or utf-8)")
+	fs.StringVar(
+		&f.NewEncoding,
+		"new-encoding",
+		scotusdb.Latin1.String(),
+		"the encoding of the new release (latin1 or utf-8)")
+}
+
+type CaseRef struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type NameChange struct {
+	ID  string `json:"id"`
+	Old string `json:"old"`
+	New string `json:"new"`
+}
+
+type VoteRef struct {
+	ID      string `json:"id"`
+	CaseID  string `json:"case-id"`
+	Justice string `json:"justice"`
+}
+
+type VoteChange struct {
+	VoteRef
+	Old string `json:"old"`
+	New string `json:"new"`
+}
+
+type Report struct {
+	Old               string        `json:"old"`
+	New               string        `json:"new"`
+	AddedCases        []*CaseRef    `json:"added-cases"`
+	RemovedCases      []*CaseRef    `json:"removed-cases"`
+	ChangedNames      []*NameChange `json:"changed-names"`
+	AddedVotes        []*VoteRef    `json:"added-votes"`
+	RemovedVotes      []*VoteRef    `json:"removed-votes"`
+	ChangedDecisions  []*VoteChange `json:"changed-decisions"`
+	ChangedVotes      []*VoteChange `json:"changed-votes"`
+	ChangedDirections []*VoteChange `json:"changed-directions"`
+}
+
+func readRelease(
+	ctx context.Context,
+	src string,
+	cacheDir string,
+	enc scotusdb.Encoding,
+) ([]*scotusdb.Term, error) {
+	if strings.HasPrefix(src, "http://") || strings.HasPrefix(src, "https://") {
+		return scotusdb.ReadRelease(
+			ctx,
+			src,
+			scotusdb.WithCacheDir(cacheDir),
+			scotusdb.WithEncoding(enc))
+	}
+	return scotusdb.ReadFile(src, scotusdb.WithEncoding(enc))
+}
+
+func casesByID(terms []*scotusdb.Term) map[string]*scotusdb.Case {
+	cases := map[string]*scotusdb.Case{}
+	for _, t := range terms {
+		for _, c := range t.Cases {
+			cases[c.ID] = c
+		}
+	}
+	return cases
+}
+
+func votesByID(c *scotusdb.Case) map[string]*scotusdb.Vote {
+	votes := map[string]*scotusdb.Vote{}
+	for _, v := range c.Votes {
+		votes[v.ID] = v
+	}
+	return votes
+}
+
+func diffVotes(
+	r *Report,
+	a *scotusdb.Case,
+	b *scotusdb.Case,
+) {
+	av := votesByID(a)
+	bv := votesByID(b)
+
+	for _, v := range a.Votes {
+		ref := VoteRef{
+			ID:      v.ID,
+			CaseID:  a.ID,
+			Justice: v.JusticeName,
+		}
+
+		w := bv[v.ID]
+		if w == nil {
+			r.RemovedVotes = append(r.RemovedVotes, &ref)
+			continue
+		}
+
+		if v.Decision != w.Decision {
+			r.ChangedDecisions = append(r.ChangedDecisions, &VoteChange{
+				VoteRef: ref,
+				Old:     string(v.Decision),
+				New:     string(w.Decision),
+			})
+		}
+
+		if v.Kind != w.Kind {
+			r.ChangedVotes = append(r.ChangedVotes, &VoteChange{
+				VoteRef: ref,
+				Old:     v.Kind,
+				New:     w.Kind,
+			})
+		}
+
+		if v.Direction != w.Direction {
+			r.ChangedDirections = append(r.ChangedDirections, &VoteChange{
+				VoteRef: ref,
+				Old:     string(v.Direction),
+				New:     string(w.Direction),
+			})
+		}
+	}
+
+	for _, w := range b.Votes {
+		if av[w.ID] == nil {
+			r.AddedVotes = append(r.AddedVotes, &VoteRef{
+				ID:      w.ID,
+				CaseID:  b.ID,
+				Justice: w.JusticeName,
+			})
+		}
+	}
+}
+
+func diff(a, b []*scotusdb.Term) *Report {
+	var r Report
+
+	ac := casesByID(a)
+	bc := casesByID(b)
+
+	for _, t := range a {
+		for _, c := range t.Cases {
+			d := bc[c.ID]
+			if d == nil {
+				r.RemovedCases = append(r.RemovedCases, &CaseRef{
+					ID:   c.ID,
+					Name: c.Name,
+				})
+				continue
+			}
+
+			if c.Name != d.Name {
+				r.ChangedNames = append(r.ChangedNames, &NameChange{
+					ID:  c.ID,
+					Old: c.Name,
+					New: d.Name,
+				})
+			}
+
+			diffVotes(&r, c, d)
+		}
+	}
+
+	for _, t := range b {
+		for _, c := range t.Cases {
+			if ac[c.ID] == nil {
+				r.AddedCases = append(r.AddedCases, &CaseRef{
+					ID:   c.ID,
+					Name: c.Name,
+				})
+			}
+		}
+	}
+
+	return &r
+}
+
+func writeText(w io.Writer, r *Report) error {
+	var err error
+	printf := func(format string, args ...interface{}) {
+		if err == nil {
+			_, err = fmt.Fprintf(w, format, args...)
+		}
+	}
+
+	printf("--- %s\n+++ %s\n", r.Old, r.New)
+
+	printf("\nadded cases (%d)\n", len(r.AddedCases))
+	for _, c := range r.AddedCases {
+		printf("  + %s %s\n", c.ID, c.Name)
+	}
+
+	printf("\nremoved cases (%d)\n", len(r.RemovedCases))
+	for _, c := range r.RemovedCases {
+		printf("  - %s %s\n", c.ID, c.Name)
+	}
+
+	printf("\nchanged names (%d)\n", len(r.ChangedNames))
+	for _, c := range r.ChangedNames {
+		printf("  %s %q -> %q\n", c.ID, c.Old, c.New)
+	}
+
+	printf("\nadded votes (%d)\n", len(r.AddedVotes))
+	for _, v := range r.AddedVotes {
+		printf("  + %s %s\n", v.ID, v.Justice)
+	}
+
+	printf("\nremoved votes (%d)\n", len(r.RemovedVotes))
+	for _, v := range r.RemovedVotes {
+		printf("  - %s %s\n", v.ID, v.Justice)
+	}
+
+	printf("\nchanged decisions (%d)\n", len(r.ChangedDecisions))
+	for _, v := range r.ChangedDecisions {
+		printf("  %s %s %s -> %s\n", v.ID, v.Justice, v.Old, v.New)
+	}
+
+	printf("\nchanged votes (%d)\n", len(r.ChangedVotes))
+	for _, v := range r.ChangedVotes {
+		printf("  %s %s %s -> %s\n", v.ID, v.Justice, v.Old, v.New)
+	}
+
+	printf("\nchanged directions (%d)\n", len(r.ChangedDirections))
+	for _, v := range r.ChangedDirections {
+		printf("  %s %s %s -> %s\n", v.ID, v.Justice, v.Old, v.New)
+	}
+
+	return err
+}
+
+func main() {
+	var flags Flags
+	flags.Register(flag.CommandLine)
+	flag.Parse()
+
+	if flag.NArg() != 2 {
+		log.Panic("usage: scdbdiff [flags] old.csv.zip|url new.csv.zip|url")
+	}
+
+	oldEnc, err := scotusdb.ParseEncoding(flags.OldEncoding)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	newEnc, err := scotusdb.ParseEncoding(flags.NewEncoding)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	ctx := context.Background()
+
+	a, err := readRelease(ctx, flag.Arg(0), flags.CacheDir, oldEnc)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	b, err := readRelease(ctx, flag.Arg(1), flags.CacheDir, newEnc)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	r := diff(a, b)
+	r.Old = flag.Arg(0)
+	r.New = flag.Arg(1)
+
+	switch flags.Format {
+	case "text":
+		err = writeText(os.Stdout, r)
+	case "json":
+		e := json.NewEncoder(os.Stdout)
+		e.SetIndent("", "  ")
+		err = e.Encode(r)
+	default:
+		err = fmt.Errorf("unknown format: %s", flags.Format)
+	}
+
+	if err != nil {
+		log.Panic(err)
+	}
+}
@@ -3,9 +3,11 @@ package main
 import (
 	"context"
 	"flag"
+	"net"
 	"os"
-	"os/exec"
 	"os/signal"
+	"regexp"
+	"time"
 
 	"github.com/kellegous/scotus/pkg/build"
 	"github.com/kellegous/scotus/pkg/data"
@@ -15,16 +17,31 @@ import (
 	"go.uber.org/zap"
 )
 
+// devAssetsDir is where webpack writes the assets in dev mode.
+const devAssetsDir = "pkg/web/dist"
+
 type Flags struct {
+	Dev       bool
 	DataDir   string
 	ResetData bool
 	HTTP      struct {
 		Addr      string
 		AssetsDir string
 	}
+	Debug struct {
+		Addr  string
+		Token string
+	}
+	Log logging.Config
 }
 
 func (f *Flags) Register(fs *flag.FlagSet) {
+	fs.BoolVar(
+		&f.Dev,
+		"dev",
+		false,
+		"run webpack in watch mode and serve assets from pkg/web/dist, unless http.assets-dir is set")
+
 	fs.StringVar(
 		&f.DataDir,
 		"data-dir",
@@ -47,30 +64,67 @@ func (f *Flags) Register(fs *flag.FlagSet) {
 		&f.HTTP.AssetsDir,
 		"http.assets-dir",
 		"",
-		"where to load web assets from")
+		"where to load web assets from (default the assets built into the binary)")
+
+	fs.StringVar(
+		&f.Debug.Addr,
+		"debug.addr",
+		"",
+		"a private address, e.g. localhost:6060, where the debug endpoints will be served")
+
+	fs.StringVar(
+		&f.Debug.Token,
+		"debug.token",
+		"",
+		"a token that, when set, exposes the debug endpoints on http.addr to requests that carry it")
+
+	f.Log.Register(fs)
 }
 
-func startWebpackWatch(
-	ctx context.Context,
-	root string,
-) error {
-	c := exec.CommandContext(ctx, "npx", "webpack", "watch", "--mode=development")
-	c.Dir = root
-	c.Stdout = os.Stdout
-	c.Stderr = os.Stderr
-	return c.Start()
+// secretFlagPattern matches the names of flags whose values must not be
+// shown in the debug config.
+var secretFlagPattern = regexp.MustCompile(`(?i)token|secret|password|key`)
+
+// configOf returns the effective value of every flag with secrets
+// redacted.
+func configOf(fs *flag.FlagSet) map[string]string {
+	config := map[string]string{}
+	fs.VisitAll(func(f *flag.Flag) {
+		v := f.Value.String()
+		if v != "" && secretFlagPattern.MatchString(f.Name) {
+			v = "[redacted]"
+		}
+		config[f.Name] = v
+	})
+	return config
 }
 
 func startHTTPServer(
 	ctx context.Context,
-	addr string,
+	l net.Listener,
 	assetsDir string,
-	data *web.Data,
+	state *web.State,
+	debug *web.Debug,
+) chan error {
+	ch := make(chan error)
+
+	go func() {
+		ch <- web.Serve(ctx, l, assetsDir, state, debug)
+	}()
+
+	return ch
+}
+
+func startDebugServer(
+	ctx context.Context,
+	l net.Listener,
+	state *web.State,
+	debug *web.Debug,
 ) chan error {
 	ch := make(chan error)
 
 	go func() {
-		ch <- web.ListenAndServe(ctx, addr, assetsDir, data)
+		ch <- web.ServeDebug(ctx, l, state, debug)
 	}()
 
 	return ch
@@ -81,7 +135,8 @@ func main() {
 	flags.Register(flag.CommandLine)
 	flag.Parse()
 
-	lg := logging.MustSetup()
+	lg, closeLog := logging.MustSetup(&flags.Log)
+	defer closeLog()
 
 	b, err := build.Read()
 	if err != nil {
@@ -104,39 +159,105 @@ func main() {
 			zap.String("path", flags.DataDir))
 	}
 
-	if err := startWebpackWatch(ctx, "."); err != nil {
-		lg.Fatal("could not start webpack watcher",
-			zap.Error(err))
+	if flags.Dev {
+		if err := startWebpackWatch(ctx, ".", lg); err != nil {
+			lg.Fatal("could not start webpack watcher",
+				zap.Error(err))
+		}
+
+		// the embedded assets are only as fresh as the last build, so serve
+		// the ones webpack is writing.
+		if flags.HTTP.AssetsDir == "" {
+			flags.HTTP.AssetsDir = devAssetsDir
+		}
 	}
 
-	m, err := data.LoadModel(ctx, flags.DataDir)
+	// the server starts before the model is loaded so that it can report
+	// that it is alive, though not yet ready.
+	l, err := net.Listen("tcp", flags.HTTP.Addr)
 	if err != nil {
-		lg.Fatal("unable to load model",
-			zap.Error(err))
+		lg.Fatal("unable to listen",
+			zap.Error(err),
+			zap.String("http.addr", flags.HTTP.Addr))
+	}
+
+	state := web.State{Build: b}
+	debug := web.Debug{
+		Token:  flags.Debug.Token,
+		Config: configOf(flag.CommandLine),
 	}
 
 	ch := startHTTPServer(
 		ctx,
-		flags.HTTP.Addr,
+		l,
 		flags.HTTP.AssetsDir,
-		&web.Data{
-			Build: b,
-			Model: m,
-		},
-	)
+		&state,
+		&debug)
+
+	// a nil channel never receives, so it is left nil when there is no
+	// debug server.
+	var dch chan error
+	if flags.Debug.Addr != "" {
+		dl, err := net.Listen("tcp", flags.Debug.Addr)
+		if err != nil {
+			lg.Fatal("unable to listen",
+				zap.Error(err),
+				zap.String("debug.addr", flags.Debug.Addr))
+		}
+		dch = startDebugServer(ctx, dl, &state, &debug)
+	}
 
 	lg.Info("server has started",
 		zap.String("http.addr", flags.HTTP.Addr),
 		zap.String("http.assets-dir", flags.HTTP.AssetsDir),
+		zap.String("debug.addr", flags.Debug.Addr),
+		zap.Bool("debug.token", flags.Debug.Token != ""),
+		zap.Bool("dev", flags.Dev),
 		zap.Bool("reset-data", flags.ResetData),
 		zap.String("data-dir", flags.DataDir),
 		zap.String("version", b.Version),
 		zap.String("name", b.Name))
 
+	start := time.Now()
+	m, err := data.LoadModel(ctx, flags.DataDir)
+	if err != nil {
+		lg.Fatal("unable to load model",
+			zap.Error(err))
+	}
+	loadDuration := time.Since(start)
+
+	sources, err := data.SourceTimes(flags.DataDir)
+	if err != nil {
+		lg.Fatal("unable to read data sources",
+			zap.Error(err))
+	}
+
+	report := data.Validate(m)
+	fields := []zap.Field{
+		zap.Int("total", report.Total()),
+	}
+	for kind, n := range report.Counts {
+		fields = append(fields, zap.Int(string(kind), n))
+	}
+	lg.Info("model validated", fields...)
+
+	state.SetData(&web.Data{
+		Model:        m,
+		Validation:   report,
+		LoadDuration: loadDuration,
+		Sources:      sources,
+	})
+
+	lg.Info("server is ready",
+		zap.Duration("load-duration", loadDuration))
+
 	select {
 	case err := <-ch:
 		lg.Fatal("http server error",
 			zap.Error(err))
+	case err := <-dch:
+		lg.Fatal("debug server error",
+			zap.Error(err))
 	case <-ctx.Done():
 		break
 	}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"os/exec"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	minWatchBackoff = time.Second
+	maxWatchBackoff = 30 * time.Second
+)
+
+// lineLogger is an io.Writer that logs each line written to it.
+type lineLogger struct {
+	log func(msg string, fields ...zap.Field)
+	buf []byte
+}
+
+func (l *lineLogger) Write(p []byte) (int, error) {
+	l.buf = append(l.buf, p...)
+	for {
+		i := bytes.IndexByte(l.buf, '\n')
+		if i < 0 {
+			break
+		}
+		l.emit(l.buf[:i])
+		l.buf = l.buf[i+1:]
+	}
+	return len(p), nil
+}
+
+// Flush logs any partial line that remains.
+func (l *lineLogger) Flush() {
+	l.emit(l.buf)
+	l.buf = nil
+}
+
+func (l *lineLogger) emit(line []byte) {
+	if s := strings.TrimRight(string(line), "\r"); s != "" {
+		l.log(s)
+	}
+}
+
+type webpack struct {
+	*exec.Cmd
+	stdout *lineLogger
+	stderr *lineLogger
+}
+
+// Wait waits for webpack to exit and logs whatever output remains.
+func (w *webpack) Wait() error {
+	err := w.Cmd.Wait()
+	w.stdout.Flush()
+	w.stderr.Flush()
+	return err
+}
+
+func startWebpack(
+	ctx context.Context,
+	root string,
+	lg *zap.Logger,
+) (*webpack, error) {
+	w := &webpack{
+		Cmd:    exec.CommandContext(ctx, "npx", "webpack", "watch", "--mode=development"),
+		stdout: &lineLogger{log: lg.Info},
+		stderr: &lineLogger{log: lg.Warn},
+	}
+	w.Dir = root
+	w.Stdout = w.stdout
+	w.Stderr = w.stderr
+
+	if err := w.Start(); err != nil {
+		return nil, err
+	}
+
+	return w, nil
+}
+
+// startWebpackWatch starts webpack in watch mode and keeps it running,
+// restarting it with a growing delay whenever it exits, until ctx is done.
+// Its output is logged. An error is returned only if it can't be started
+// the first time.
+func startWebpackWatch(
+	ctx context.Context,
+	root string,
+	lg *zap.Logger,
+) error {
+	// webpack's output is relayed line by line, so callers and stacks
+	// would only point here.
+	lg = lg.Named("webpack").WithOptions(
+		zap.WithCaller(false),
+		zap.AddStacktrace(zapcore.DPanicLevel))
+
+	c, err := startWebpack(ctx, root, lg)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		backoff := minWatchBackoff
+		for {
+			start := time.Now()
+			err := c.Wait()
+			if ctx.Err() != nil {
+				return
+			}
+
+			// a watcher that ran for a while before exiting starts over with
+			// the shortest delay.
+			if time.Since(start) > maxWatchBackoff {
+				backoff = minWatchBackoff
+			}
+
+			lg.Error("webpack watcher exited",
+				zap.Error(err))
+
+			for {
+				lg.Info("restarting webpack watcher",
+					zap.Duration("delay", backoff))
+
+				select {
+				case <-time.After(backoff):
+				case <-ctx.Done():
+					return
+				}
+
+				if backoff *= 2; backoff > maxWatchBackoff {
+					backoff = maxWatchBackoff
+				}
+
+				c, err = startWebpack(ctx, root, lg)
+				if err == nil {
+					break
+				}
+
+				lg.Error("unable to restart webpack watcher",
+					zap.Error(err))
+			}
+		}
+	}()
+
+	return nil
+}
@@ -1,9 +1,13 @@
 package async
 
+// Future is the result of a computation that may not have finished. Once
+// fulfilled, the result is kept so that it can be resolved any number of
+// times.
 type Future[T any] struct {
 	empty T
-	rch   chan T
-	ech   chan error
+	done  chan struct{}
+	val   T
+	err   error
 }
 
 func newFuture[T any](
@@ -11,24 +15,22 @@ func newFuture[T any](
 ) *Future[T] {
 	return &Future[T]{
 		empty: empty,
-		rch:   make(chan T, 1),
-		ech:   make(chan error, 1),
+		done:  make(chan struct{}),
 	}
 }
 
 func (f *Future[T]) fulfill(r T, err error) {
 	if err != nil {
-		f.ech <- err
+		f.val = f.empty
 	} else {
-		f.rch <- r
+		f.val = r
 	}
+	f.err = err
+	close(f.done)
 }
 
+// Resolve waits for the future to be fulfilled and returns its result.
 func (f *Future[T]) Resolve() (T, error) {
-	select {
-	case r := <-f.rch:
-		return r, nil
-	case err := <-f.ech:
-		return f.empty, err
-	}
+	<-f.done
+	return f.val, f.err
 }
@@ -0,0 +1,39 @@
+package async
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestResolveTwice(t *testing.T) {
+	f := Run(func() (int, error) {
+		return 42, nil
+	}, 0)
+
+	for i := 0; i < 2; i++ {
+		v, err := f.Resolve()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v != 42 {
+			t.Fatalf("expected 42, got %d", v)
+		}
+	}
+}
+
+func TestResolveTwiceAfterFailure(t *testing.T) {
+	errFailed := errors.New("failed")
+	f := Run(func() (int, error) {
+		return 42, errFailed
+	}, -1)
+
+	for i := 0; i < 2; i++ {
+		v, err := f.Resolve()
+		if !errors.Is(err, errFailed) {
+			t.Fatalf("expected %v, got %v", errFailed, err)
+		}
+		if v != -1 {
+			t.Fatalf("expected the empty value -1, got %d", v)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package async
+
+import (
+	"fmt"
+
+	"go.uber.org/multierr"
+)
+
+// Group runs named functions concurrently and collects every failure,
+// rather than stopping at the first one.
+type Group struct {
+	names   []string
+	futures []*Future[struct{}]
+}
+
+// Go runs fn in a new goroutine. The name identifies fn in the error
+// returned by Wait.
+func (g *Group) Go(name string, fn func() error) {
+	g.names = append(g.names, name)
+	g.futures = append(g.futures, Run(
+		func() (struct{}, error) {
+			return struct{}{}, fn()
+		},
+		struct{}{}))
+}
+
+// Wait waits for all of the functions to return. The error, if any,
+// combines the errors of every function that failed, each prefixed with
+// its name. multierr.Errors can be used to take it apart.
+func (g *Group) Wait() error {
+	var errs error
+	for i, f := range g.futures {
+		if _, err := f.Resolve(); err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("%s: %w", g.names[i], err))
+		}
+	}
+	return errs
+}
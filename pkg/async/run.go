@@ -1,11 +1,37 @@
 package async
 
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+// PanicError is the error a future fails with when its function panics.
+type PanicError struct {
+	Value interface{}
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v\n\n%s", e.Value, e.Stack)
+}
+
+// Run calls fn in a new goroutine and returns a future for its result. If
+// fn panics, the future fails with a *PanicError instead of crashing the
+// process.
 func Run[T any](
 	fn func() (T, error),
 	empty T,
 ) *Future[T] {
 	f := newFuture(empty)
 	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				f.fulfill(empty, &PanicError{
+					Value: r,
+					Stack: debug.Stack(),
+				})
+			}
+		}()
 		f.fulfill(fn())
 	}()
 	return f
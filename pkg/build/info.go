@@ -1,52 +1,110 @@
 package build
 
 import (
-	"debug/buildinfo"
-	"fmt"
-	"os"
+	"runtime"
 	"runtime/debug"
 	"time"
 
 	"github.com/kellegous/buildname"
 )
 
+// DevVersion is the version of builds that carry no version information,
+// such as those from go run or from a source tarball.
+const DevVersion = "dev"
+
+// version and buildTime override what is found in the build info. They
+// are meant to be set by the linker, e.g.
+//
+//	go build -ldflags "-X github.com/kellegous/scotus/pkg/build.version=v1.2.3
+//	  -X github.com/kellegous/scotus/pkg/build.buildTime=2022-08-01T00:00:00Z"
+//
+// buildTime must be in RFC 3339 format; any other value is ignored.
+var (
+	version   string
+	buildTime string
+)
+
 type Info struct {
 	Version     string      `json:"version"`
 	Name        string      `json:"name"`
-	Time        time.Time   `json:"time"`
+	Time        *time.Time  `json:"time,omitempty"`
+	Modified    bool        `json:"modified"`
+	Module      *Module     `json:"module,omitempty"`
 	Environment Environment `json:"go"`
 	Deps        []*Module   `json:"deps"`
 }
 
+// Read describes the running binary. Version and time come from the linker
+// overrides when set, then from the VCS information stamped by the go
+// command and finally from the main module. Builds with none of those get
+// DevVersion and no time.
 func Read() (*Info, error) {
-	bi, err := buildinfo.ReadFile(os.Args[0])
-	if err != nil {
-		return nil, err
+	info := Info{
+		Version: DevVersion,
+		Deps:    []*Module{},
+		Environment: Environment{
+			Version: runtime.Version(),
+			Arch:    runtime.GOARCH,
+			OS:      runtime.GOOS,
+		},
+	}
+
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		readBuildInfo(&info, bi)
+	}
+
+	if version != "" {
+		info.Version = version
 	}
 
+	// like vcs.time, a malformed build time is left out rather than
+	// keeping the server from starting.
+	if t, err := time.Parse(time.RFC3339, buildTime); err == nil {
+		info.Time = &t
+	}
+
+	info.Name = buildname.FromVersion(info.Version)
+
+	return &info, nil
+}
+
+func readBuildInfo(info *Info, bi *debug.BuildInfo) {
 	settings := map[string]string{}
 	for _, setting := range bi.Settings {
 		settings[setting.Key] = setting.Value
 	}
 
-	version := settings["vcs.revision"]
+	if bi.Main.Path != "" {
+		info.Module = &Module{
+			Name:    bi.Main.Path,
+			Version: bi.Main.Version,
+		}
+	}
 
-	t, err := time.Parse(time.RFC3339, settings["vcs.time"])
-	if err != nil {
-		return nil, fmt.Errorf("vcs.time: %w", err)
+	if v := settings["vcs.revision"]; v != "" {
+		info.Version = v
+	} else if v := bi.Main.Version; v != "" && v != "(devel)" {
+		info.Version = v
 	}
 
-	return &Info{
-		Version: version,
-		Name:    buildname.FromVersion(version),
-		Time:    t,
-		Environment: Environment{
-			Version: bi.GoVersion,
-			Arch:    settings["GOARCH"],
-			OS:      settings["GOOS"],
-		},
-		Deps: toModules(bi),
-	}, nil
+	// a malformed time is not worth failing over, it is just left out.
+	if t, err := time.Parse(time.RFC3339, settings["vcs.time"]); err == nil {
+		info.Time = &t
+	}
+
+	info.Modified = settings["vcs.modified"] == "true"
+
+	if bi.GoVersion != "" {
+		info.Environment.Version = bi.GoVersion
+	}
+	if v := settings["GOARCH"]; v != "" {
+		info.Environment.Arch = v
+	}
+	if v := settings["GOOS"]; v != "" {
+		info.Environment.OS = v
+	}
+
+	info.Deps = toModules(bi)
 }
 
 func toModules(bi *debug.BuildInfo) []*Module {
@@ -0,0 +1,60 @@
+package csv
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DefaultDateLayouts are the date layouts that are tried, in order, when a
+// reader hasn't been given its own.
+var DefaultDateLayouts = []string{
+	// SCDB, e.g. 11/18/1946
+	"1/2/2006",
+
+	// ISO 8601, e.g. 2021-10-18
+	"2006-01-02",
+
+	// AP style as used by the court, e.g. Oct. 18, 2021 and June 24, 2022
+	"Jan. 2, 2006",
+	"January 2, 2006",
+}
+
+var errMissingDate = errors.New("missing date")
+
+// parseDate parses v with the first of the layouts that matches. Since
+// time.Parse requires four digit years for 2006 and checks the ranges of
+// months and days, dates like 2/30/2020 or 1/2/46 are rejected.
+func parseDate(v string, layouts []string) (time.Time, error) {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return time.Time{}, errMissingDate
+	}
+
+	// September is the one month whose AP abbreviation isn't three
+	// letters.
+	v = strings.Replace(v, "Sept.", "Sep.", 1)
+
+	var first error
+	for _, layout := range layouts {
+		t, err := time.ParseInLocation(layout, v, time.UTC)
+		if err == nil {
+			return t, nil
+		}
+
+		if first == nil {
+			first = err
+		}
+	}
+
+	if first == nil {
+		return time.Time{}, fmt.Errorf("invalid date %q: no layouts", v)
+	}
+
+	return time.Time{}, fmt.Errorf(
+		"invalid date %q, expected one of %q: %w",
+		v,
+		layouts,
+		first)
+}
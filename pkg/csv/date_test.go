@@ -0,0 +1,99 @@
+package csv
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseDate(t *testing.T) {
+	date := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		value    string
+		expected time.Time
+	}{
+		{"11/18/1946", date(1946, time.November, 18)},
+		{"2/10/1947", date(1947, time.February, 10)},
+		{"02/03/1947", date(1947, time.February, 3)},
+		{"  6/24/2022 ", date(2022, time.June, 24)},
+		{"2021-10-18", date(2021, time.October, 18)},
+		{"Oct. 18, 2021", date(2021, time.October, 18)},
+		{"Sept. 30, 2021", date(2021, time.September, 30)},
+		{"Sep. 30, 2021", date(2021, time.September, 30)},
+		{"June 24, 2022", date(2022, time.June, 24)},
+		{"May 2, 2022", date(2022, time.May, 2)},
+		{"2/29/2020", date(2020, time.February, 29)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			d, err := parseDate(test.value, DefaultDateLayouts)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !d.Equal(test.expected) || d.Location() != time.UTC {
+				t.Fatalf("expected %s, got %s", test.expected, d)
+			}
+		})
+	}
+}
+
+func TestParseDateErrors(t *testing.T) {
+	tests := []string{
+		// two digit years
+		"1/2/46",
+		"46-01-02",
+
+		// out of range
+		"2/30/2020",
+		"2/29/2021",
+		"13/1/2020",
+		"2021-10-32",
+		"Oct. 32, 2021",
+
+		// not dates
+		"unknown",
+		"1946",
+		"11/18/1946 12:00",
+		"Sept 30, 2021",
+		"Sept. 30",
+	}
+
+	for _, test := range tests {
+		t.Run(test, func(t *testing.T) {
+			if d, err := parseDate(test, DefaultDateLayouts); err == nil {
+				t.Fatalf("expected an error, got %s", d)
+			}
+		})
+	}
+}
+
+func TestParseDateMissing(t *testing.T) {
+	for _, v := range []string{"", "  "} {
+		if _, err := parseDate(v, DefaultDateLayouts); !errors.Is(err, errMissingDate) {
+			t.Fatalf("expected errMissingDate for %q, got %v", v, err)
+		}
+	}
+}
+
+func TestParseDateLayouts(t *testing.T) {
+	d, err := parseDate("18.11.1946", []string{"2.1.2006"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := time.Date(1946, time.November, 18, 0, 0, 0, 0, time.UTC); !d.Equal(expected) {
+		t.Fatalf("expected %s, got %s", expected, d)
+	}
+
+	if _, err := parseDate("11/18/1946", []string{"2.1.2006"}); err == nil {
+		t.Fatal("expected an error for a date not in the given layouts")
+	}
+
+	if _, err := parseDate("11/18/1946", nil); err == nil {
+		t.Fatal("expected an error without any layouts")
+	}
+}
@@ -0,0 +1,135 @@
+package csv
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+// Decode reads the next row and stores it in the struct pointed to by v.
+// It returns io.EOF when there are no more rows. A lenient reader skips
+// over rows that can't be decoded.
+func (r *Reader) Decode(v interface{}) error {
+	for {
+		row, err := r.Next()
+		if err != nil {
+			return err
+		}
+
+		err = row.Decode(v)
+		if err == nil || !isMalformed(err) {
+			return err
+		}
+
+		if err := r.Skip(err); err != nil {
+			return err
+		}
+	}
+}
+
+// Decode stores the row in the struct pointed to by v. Fields are matched
+// to columns with a csv tag, e.g. `csv:"caseName"`. Pointer fields are
+// optional and are left nil when the column is blank. Fields whose types
+// implement encoding.TextUnmarshaler are decoded with UnmarshalText. If
+// any field can't be decoded, a *DecodeError with all of the failures is
+// returned.
+func (r *Row) Decode(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf("decode requires a non-nil pointer, got %T", v)
+	}
+
+	rv = rv.Elem()
+	if rv.Kind() != reflect.Struct {
+		return fmt.Errorf("decode requires a pointer to a struct, got %T", v)
+	}
+
+	var errs []*ParseError
+	for _, f := range fieldsOf(rv.Type()) {
+		s, pe := r.get(f.column)
+		if pe == nil {
+			if err := setValue(f.settable(rv), s, r.layouts); err != nil {
+				pe = r.newError(f.column, s, err)
+			}
+		}
+
+		if pe != nil {
+			pe.Field = f.name
+			errs = append(errs, pe)
+		}
+	}
+
+	if len(errs) > 0 {
+		return &DecodeError{Errors: errs}
+	}
+
+	return nil
+}
+
+func setValue(v reflect.Value, s string, layouts []string) error {
+	if v.Kind() == reflect.Ptr {
+		if strings.TrimSpace(s) == "" {
+			v.Set(reflect.Zero(v.Type()))
+			return nil
+		}
+
+		p := reflect.New(v.Type().Elem())
+		if err := setValue(p.Elem(), s, layouts); err != nil {
+			return err
+		}
+		v.Set(p)
+		return nil
+	}
+
+	// time.Time is a TextUnmarshaler, but it only understands RFC 3339.
+	if v.Type() == timeType {
+		t, err := parseDate(s, layouts)
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(t))
+		return nil
+	}
+
+	if reflect.PtrTo(v.Type()).Implements(textUnmarshalerType) {
+		return v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s))
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(strings.TrimSpace(s), 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		i, err := strconv.ParseUint(strings.TrimSpace(s), 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(i)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(strings.TrimSpace(s), v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(strings.TrimSpace(s))
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	default:
+		return errors.New("unsupported type " + v.Type().String())
+	}
+
+	return nil
+}
@@ -0,0 +1,175 @@
+package csv
+
+import (
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+type testVote struct {
+	ID       string `csv:"voteId"`
+	Majority int    `csv:"majority"`
+	MajVotes *int   `csv:"majVotes"`
+}
+
+const testVotes = `voteId,majority,majVotes
+1946-001-01-01-01-01,2,5
+1946-001-01-01-01-02,x,y
+1946-001-01-01-01-03,1,
+`
+
+func TestDecodeErrorAs(t *testing.T) {
+	r, err := NewReader(strings.NewReader(testVotes))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var v testVote
+	if err := r.Decode(&v); err != nil {
+		t.Fatal(err)
+	}
+
+	err = r.Decode(&v)
+
+	var de *DecodeError
+	if !errors.As(err, &de) {
+		t.Fatalf("expected a *DecodeError, got %v", err)
+	}
+	if n := len(de.Errors); n != 2 {
+		t.Fatalf("expected 2 field errors, got %d", n)
+	}
+
+	var pe *ParseError
+	if !errors.As(err, &pe) {
+		t.Fatalf("expected a *ParseError, got %v", err)
+	}
+	if pe.Record != 2 || pe.Column != "majority" || pe.Value != "x" {
+		t.Fatalf(
+			"expected record 2, column majority, value x, got %d, %s, %s",
+			pe.Record,
+			pe.Column,
+			pe.Value)
+	}
+
+	var ne *strconv.NumError
+	if !errors.As(err, &ne) {
+		t.Fatalf("expected a *strconv.NumError, got %v", err)
+	}
+}
+
+func TestLenientDecodeSkipsMalformedRows(t *testing.T) {
+	r, err := NewReader(strings.NewReader(testVotes), Lenient(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var ids []string
+	for {
+		var v testVote
+		err := r.Decode(&v)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, v.ID)
+	}
+
+	if len(ids) != 2 ||
+		ids[0] != "1946-001-01-01-01-01" ||
+		ids[1] != "1946-001-01-01-01-03" {
+		t.Fatalf("expected the first and third votes, got %q", ids)
+	}
+
+	if n := len(r.Skipped()); n != 1 {
+		t.Fatalf("expected 1 skipped row, got %d", n)
+	}
+}
+
+func TestLenientDecodeGivesUp(t *testing.T) {
+	r, err := NewReader(strings.NewReader(testVotes), Lenient(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for {
+		var v testVote
+		err := r.Decode(&v)
+		if err == io.EOF {
+			t.Fatal("expected the reader to give up")
+		} else if err != nil {
+			if !errors.Is(err, ErrTooManySkipped) {
+				t.Fatalf("expected ErrTooManySkipped, got %v", err)
+			}
+			return
+		}
+	}
+}
+
+type testCase struct {
+	ID string `csv:"caseId"`
+}
+
+type testDates struct {
+	Decided string `csv:"dateDecision"`
+}
+
+type testEmbedded struct {
+	*testCase
+	testDates
+	Name string `csv:"caseName"`
+}
+
+type ExportedCase struct {
+	ID string `csv:"caseId"`
+}
+
+type testExportedEmbedded struct {
+	*ExportedCase
+	Name string `csv:"caseName"`
+}
+
+func TestDecodeEmbedded(t *testing.T) {
+	const src = "caseId,caseName,dateDecision\n1946-001,Everson v. Board of Education,2/10/1947\n"
+
+	t.Run("unexported pointer", func(t *testing.T) {
+		r, err := NewReader(strings.NewReader(src))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var v testEmbedded
+		if err := r.Decode(&v); err != nil {
+			t.Fatal(err)
+		}
+
+		if v.testCase != nil {
+			t.Fatalf(
+				"expected the unexported pointer to be ignored, got %v",
+				v.testCase)
+		}
+		if v.Decided != "2/10/1947" || v.Name != "Everson v. Board of Education" {
+			t.Fatalf("expected the other fields to be decoded, got %+v", v)
+		}
+	})
+
+	t.Run("exported pointer", func(t *testing.T) {
+		r, err := NewReader(strings.NewReader(src))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var v testExportedEmbedded
+		if err := r.Decode(&v); err != nil {
+			t.Fatal(err)
+		}
+
+		if v.ExportedCase == nil || v.ID != "1946-001" {
+			t.Fatalf(
+				"expected the embedded pointer to be allocated, got %+v",
+				v.ExportedCase)
+		}
+	})
+}
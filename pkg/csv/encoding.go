@@ -0,0 +1,75 @@
+package csv
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"unicode/utf8"
+)
+
+var utf8BOM = []byte{0xef, 0xbb, 0xbf}
+
+func skipBOM(r io.Reader) (io.Reader, error) {
+	br := bufio.NewReader(r)
+	b, err := br.Peek(len(utf8BOM))
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	if bytes.Equal(b, utf8BOM) {
+		if _, err := br.Discard(len(utf8BOM)); err != nil {
+			return nil, err
+		}
+	}
+
+	return br, nil
+}
+
+// latin1Reader transcodes ISO-8859-1 into UTF-8. Every byte in Latin-1 is
+// the code point of the same value, so no tables are needed.
+type latin1Reader struct {
+	r       *bufio.Reader
+	buf     [utf8.UTFMax]byte
+	pending []byte
+}
+
+func newLatin1Reader(r io.Reader) io.Reader {
+	return &latin1Reader{
+		r: bufio.NewReader(r),
+	}
+}
+
+func (l *latin1Reader) Read(p []byte) (int, error) {
+	n := 0
+	for n < len(p) {
+		if len(l.pending) > 0 {
+			c := copy(p[n:], l.pending)
+			l.pending = l.pending[c:]
+			n += c
+			continue
+		}
+
+		// don't block for more input once something can be returned.
+		if n > 0 && l.r.Buffered() == 0 {
+			break
+		}
+
+		b, err := l.r.ReadByte()
+		if err != nil {
+			if n > 0 {
+				return n, nil
+			}
+			return 0, err
+		}
+
+		if b < utf8.RuneSelf {
+			p[n] = b
+			n++
+			continue
+		}
+
+		l.pending = l.buf[:utf8.EncodeRune(l.buf[:], rune(b))]
+	}
+
+	return n, nil
+}
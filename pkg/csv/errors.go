@@ -0,0 +1,95 @@
+package csv
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ParseError describes a value in a row that could not be read, along with
+// where the row can be found in the source.
+type ParseError struct {
+	// Record is the 1-based number of the record, not counting the header.
+	Record int
+
+	// Line is the line in the source where the record starts.
+	Line int
+
+	// Column is the name of the column in the header, if known.
+	Column string
+
+	// Field is the name of the struct field being decoded, if any.
+	Field string
+
+	// Value is the raw value of the column, if known.
+	Value string
+
+	Err error
+}
+
+func (e *ParseError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "record %d (line %d)", e.Record, e.Line)
+	if e.Column != "" {
+		fmt.Fprintf(&b, ", column %s", e.Column)
+	}
+	if e.Field != "" {
+		fmt.Fprintf(&b, ", field %s", e.Field)
+	}
+	if e.Value != "" {
+		fmt.Fprintf(&b, ", value %q", e.Value)
+	}
+	fmt.Fprintf(&b, ": %s", e.Err)
+	return b.String()
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// DecodeError collects the errors for every field of a struct that could
+// not be decoded from a row.
+type DecodeError struct {
+	Errors []*ParseError
+}
+
+func (e *DecodeError) Error() string {
+	msgs := make([]string, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Unwrap returns the errors for each field, so that errors.As finds the
+// *ParseError of the first one.
+func (e *DecodeError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// Is reports whether any of the field errors matches target. The errors
+// package only follows Unwrap() []error from Go 1.20, so the field errors
+// are walked here as well.
+func (e *DecodeError) Is(target error) bool {
+	for _, err := range e.Errors {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As finds the first field error that matches target, for the same reason
+// as Is.
+func (e *DecodeError) As(target interface{}) bool {
+	for _, err := range e.Errors {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,105 @@
+package csv
+
+import (
+	"reflect"
+	"sync"
+	"time"
+)
+
+var (
+	timeType = reflect.TypeOf(time.Time{})
+
+	fieldsCache sync.Map // map[reflect.Type][]*field
+)
+
+type field struct {
+	name   string
+	column string
+	index  []int
+}
+
+// settable returns the field in v, allocating any nil embedded structs
+// along the way.
+func (f *field) settable(v reflect.Value) reflect.Value {
+	for _, i := range f.index {
+		if v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(i)
+	}
+	return v
+}
+
+// value returns the field in v or false if one of the embedded structs
+// along the way is nil.
+func (f *field) value(v reflect.Value) (reflect.Value, bool) {
+	for _, i := range f.index {
+		if v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(i)
+	}
+	return v, true
+}
+
+// fieldsOf finds the fields of a struct type that are tagged with a csv
+// column. Fields without a tag, or with a tag of "-", are ignored. The
+// fields of untagged embedded structs are treated as though they were
+// fields of the outer struct, except for those embedded by a pointer to an
+// unexported type.
+func fieldsOf(t reflect.Type) []*field {
+	if f, ok := fieldsCache.Load(t); ok {
+		return f.([]*field)
+	}
+
+	f, _ := fieldsCache.LoadOrStore(t, appendFieldsOf(nil, t, nil))
+	return f.([]*field)
+}
+
+func appendFieldsOf(
+	fields []*field,
+	t reflect.Type,
+	index []int,
+) []*field {
+	for i, n := 0, t.NumField(); i < n; i++ {
+		sf := t.Field(i)
+		tag := sf.Tag.Get("csv")
+
+		idx := make([]int, len(index)+1)
+		copy(idx, index)
+		idx[len(index)] = i
+
+		if sf.Anonymous && tag == "" {
+			et := sf.Type
+			if et.Kind() == reflect.Ptr {
+				// as with encoding/json, a nil pointer to an unexported
+				// struct can't be allocated, so its fields are ignored.
+				if sf.PkgPath != "" {
+					continue
+				}
+				et = et.Elem()
+			}
+			if et.Kind() == reflect.Struct {
+				fields = appendFieldsOf(fields, et, idx)
+				continue
+			}
+		}
+
+		if sf.PkgPath != "" || tag == "" || tag == "-" {
+			continue
+		}
+
+		fields = append(fields, &field{
+			name:   sf.Name,
+			column: tag,
+			index:  idx,
+		})
+	}
+	return fields
+}
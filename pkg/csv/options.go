@@ -0,0 +1,99 @@
+package csv
+
+type Options struct {
+	lenient     bool
+	maxSkipped  int
+	delimiter   rune
+	comment     rune
+	stripBOM    bool
+	latin1      bool
+	trimHeaders bool
+	aliases     map[string]string
+	layouts     []string
+	columns     []string
+}
+
+func (o *Options) apply(opts []Option) {
+	o.delimiter = ','
+	for _, opt := range opts {
+		opt(o)
+	}
+}
+
+type Option func(o *Options)
+
+// Lenient makes the reader skip malformed rows rather than failing. Up to
+// maxSkipped rows will be skipped before the reader gives up.
+func Lenient(maxSkipped int) Option {
+	return func(o *Options) {
+		o.lenient = true
+		o.maxSkipped = maxSkipped
+	}
+}
+
+// WithDelimiter sets the field delimiter, e.g. '\t' for TSV files.
+func WithDelimiter(d rune) Option {
+	return func(o *Options) {
+		o.delimiter = d
+	}
+}
+
+// WithComment causes lines beginning with c to be ignored.
+func WithComment(c rune) Option {
+	return func(o *Options) {
+		o.comment = c
+	}
+}
+
+// StripBOM removes a leading UTF-8 byte order mark, as is written by
+// many spreadsheet applications.
+func StripBOM() Option {
+	return func(o *Options) {
+		o.stripBOM = true
+	}
+}
+
+// Latin1 transcodes the input from ISO-8859-1 into UTF-8. Older SCDB
+// releases are encoded this way.
+func Latin1() Option {
+	return func(o *Options) {
+		o.latin1 = true
+	}
+}
+
+// TrimHeaders removes leading and trailing space from column names.
+func TrimHeaders() Option {
+	return func(o *Options) {
+		o.trimHeaders = true
+	}
+}
+
+// WithAliases maps alternate column names to the canonical names used by
+// callers, so that a renamed column upstream can still be found.
+func WithAliases(aliases map[string]string) Option {
+	return func(o *Options) {
+		if o.aliases == nil {
+			o.aliases = map[string]string{}
+		}
+		for alias, name := range aliases {
+			o.aliases[alias] = name
+		}
+	}
+}
+
+// WithDateLayouts sets the layouts, in the form used by time.Parse, that
+// are tried in order when parsing dates. Writers format dates with the
+// first layout.
+func WithDateLayouts(layouts ...string) Option {
+	return func(o *Options) {
+		o.layouts = layouts
+	}
+}
+
+// WithColumns selects the columns, and their order, that a writer will
+// emit. By default, a writer emits every tagged field in struct order.
+func WithColumns(columns ...string) Option {
+	return func(o *Options) {
+		o.columns = columns
+	}
+}
@@ -2,17 +2,53 @@ package csv
 
 import (
 	"encoding/csv"
+	"errors"
 	"fmt"
 	"io"
+	"strings"
 )
 
+// ErrTooManySkipped is returned by a lenient reader once it has skipped
+// more rows than it was allowed.
+var ErrTooManySkipped = errors.New("too many malformed rows")
+
 type Reader struct {
-	r      *csv.Reader
-	fields map[string]int
+	r       *csv.Reader
+	fields  map[string]int
+	columns []string
+	record  int
+	opts    Options
+	skipped []error
 }
 
-func NewReader(r io.Reader) (*Reader, error) {
+func NewReader(r io.Reader, opts ...Option) (*Reader, error) {
+	var o Options
+	o.apply(opts)
+	if o.layouts == nil {
+		o.layouts = DefaultDateLayouts
+	}
+
+	// the BOM is stripped before transcoding, which would otherwise turn
+	// its bytes into three Latin-1 characters.
+	if o.stripBOM {
+		var err error
+		r, err = skipBOM(r)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if o.latin1 {
+		r = newLatin1Reader(r)
+	}
+
 	cr := csv.NewReader(r)
+	cr.Comma = o.delimiter
+	cr.Comment = o.comment
+
+	// column counts are checked in Next so the error can carry the
+	// position of the row.
+	cr.FieldsPerRecord = -1
 
 	hdrs, err := cr.Read()
 	if err == io.EOF {
@@ -23,30 +59,116 @@ func NewReader(r io.Reader) (*Reader, error) {
 
 	fields := map[string]int{}
 	for i, hdr := range hdrs {
+		if o.trimHeaders {
+			hdr = strings.TrimSpace(hdr)
+		}
+		if name, ok := o.aliases[hdr]; ok {
+			hdr = name
+		}
 		fields[hdr] = i
+		hdrs[i] = hdr
 	}
 
 	return &Reader{
-		r:      cr,
-		fields: fields,
+		r:       cr,
+		fields:  fields,
+		columns: hdrs,
+		opts:    o,
 	}, nil
 }
 
+// Columns returns the names of the columns in the order they appear, after
+// any trimming and aliasing.
+func (r *Reader) Columns() []string {
+	return r.columns
+}
+
+// Next returns the next row. A lenient reader skips over rows that can't
+// be parsed or that have the wrong number of columns.
 func (r *Reader) Next() (*Row, error) {
+	for {
+		row, err := r.next()
+		if err == nil || !isMalformed(err) {
+			return row, err
+		}
+
+		if err := r.Skip(err); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// Skip records that a row was skipped because of err. Readers that are
+// not lenient return err as is. Lenient readers return nil until they
+// have skipped more rows than they are allowed.
+func (r *Reader) Skip(err error) error {
+	if !r.opts.lenient {
+		return err
+	}
+
+	r.skipped = append(r.skipped, err)
+	if len(r.skipped) > r.opts.maxSkipped {
+		return fmt.Errorf(
+			"%w: skipped %d rows, last error: %s",
+			ErrTooManySkipped,
+			len(r.skipped),
+			err)
+	}
+
+	return nil
+}
+
+// Skipped returns the reasons for every row that has been skipped.
+func (r *Reader) Skipped() []error {
+	return r.skipped
+}
+
+// isMalformed determines if err is due to malformed data rather than a
+// failure to read the underlying source.
+func isMalformed(err error) bool {
+	var ce *csv.ParseError
+	var pe *ParseError
+	var de *DecodeError
+	return errors.As(err, &ce) || errors.As(err, &pe) || errors.As(err, &de)
+}
+
+func (r *Reader) next() (*Row, error) {
 	vals, err := r.r.Read()
-	if err != nil {
+	if err == io.EOF {
 		return nil, err
 	}
 
-	if len(vals) != len(r.fields) {
-		return nil, fmt.Errorf(
+	// a record that can't be parsed still counts, so the records that
+	// follow it are numbered as they are in the source.
+	r.record++
+
+	var ce *csv.ParseError
+	if errors.As(err, &ce) {
+		return nil, &ParseError{
+			Record: r.record,
+			Line:   ce.StartLine,
+			Err:    err,
+		}
+	} else if err != nil {
+		return nil, err
+	}
+
+	line, _ := r.r.FieldPos(0)
+
+	row := &Row{
+		fields:  r.fields,
+		values:  vals,
+		record:  r.record,
+		line:    line,
+		layouts: r.opts.layouts,
+	}
+
+	if len(vals) != len(r.columns) {
+		return nil, row.newError("", "", fmt.Errorf(
 			"wrong number of columns in row, expected %d got %d",
-			len(r.fields),
-			len(vals))
+			len(r.columns),
+			len(vals)))
 	}
 
-	return &Row{
-		fields: r.fields,
-		values: vals,
-	}, nil
+	return row, nil
 }
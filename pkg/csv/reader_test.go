@@ -0,0 +1,79 @@
+package csv
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestNewReaderStripsBOMBeforeTranscoding(t *testing.T) {
+	// caseName in Latin-1, preceded by a UTF-8 BOM.
+	src := "\xef\xbb\xbfcaseId,caseName\n1791-001,Ex parte Pe\xf1a\n"
+
+	r, err := NewReader(strings.NewReader(src), StripBOM(), Latin1())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cols := r.Columns(); len(cols) != 2 || cols[0] != "caseId" {
+		t.Fatalf("expected columns [caseId caseName], got %q", cols)
+	}
+
+	row, err := r.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	id, err := row.Get("caseId")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id != "1791-001" {
+		t.Fatalf("expected caseId 1791-001, got %q", id)
+	}
+
+	name, err := row.Get("caseName")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name != "Ex parte Peña" {
+		t.Fatalf("expected caseName Ex parte Peña, got %q", name)
+	}
+}
+
+func TestNextNumbersRecordsAfterParseErrors(t *testing.T) {
+	// the first record has a bare quote.
+	src := "caseId,caseName\n1791-001,West \"v.\" Barnes\n1791-002,Hayburn's Case\n"
+
+	r, err := NewReader(strings.NewReader(src), Lenient(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	row, err := r.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if row.record != 2 || row.line != 3 {
+		t.Fatalf(
+			"expected record 2 on line 3, got record %d on line %d",
+			row.record,
+			row.line)
+	}
+
+	skipped := r.Skipped()
+	if len(skipped) != 1 {
+		t.Fatalf("expected 1 skipped row, got %d", len(skipped))
+	}
+
+	var pe *ParseError
+	if !errors.As(skipped[0], &pe) {
+		t.Fatalf("expected a *ParseError, got %v", skipped[0])
+	}
+	if pe.Record != 1 || pe.Line != 2 {
+		t.Fatalf(
+			"expected record 1 on line 2, got record %d on line %d",
+			pe.Record,
+			pe.Line)
+	}
+}
@@ -2,6 +2,7 @@ package csv
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -9,16 +10,52 @@ import (
 )
 
 type Row struct {
-	fields map[string]int
-	values []string
+	fields  map[string]int
+	values  []string
+	record  int
+	line    int
+	layouts []string
 }
 
-func (r *Row) Get(name string) (string, error) {
+// Record returns the 1-based number of the row, not counting the header.
+func (r *Row) Record() int {
+	return r.record
+}
+
+// Line returns the line in the source where the row starts.
+func (r *Row) Line() int {
+	return r.line
+}
+
+func (r *Row) newError(column, value string, err error) *ParseError {
+	return &ParseError{
+		Record: r.record,
+		Line:   r.line,
+		Column: column,
+		Value:  value,
+		Err:    err,
+	}
+}
+
+func (r *Row) get(name string) (string, *ParseError) {
 	if i, ok := r.fields[name]; ok {
 		return r.values[i], nil
 	}
 
-	return "", fmt.Errorf("unknown field: %s", name)
+	return "", r.newError(name, "", errors.New("unknown field"))
+}
+
+func (r *Row) Get(name string) (string, error) {
+	v, err := r.get(name)
+	if err != nil {
+		return "", err
+	}
+	return v, nil
+}
+
+// Values returns the values of the row in column order.
+func (r *Row) Values() []string {
+	return r.values
 }
 
 func (r *Row) GetInt(
@@ -32,7 +69,7 @@ func (r *Row) GetInt(
 
 	i, err := fn(v)
 	if err != nil {
-		return 0, fmt.Errorf("%s cannot be made into an int %w", name, err)
+		return 0, r.newError(name, v, fmt.Errorf("cannot be made into an int: %w", err))
 	}
 
 	return i, nil
@@ -53,7 +90,7 @@ func (r *Row) GetFloat64(
 
 	f, err := fn(v)
 	if err != nil {
-		return 0, fmt.Errorf("%s cannot be made into a float64: %w", name, err)
+		return 0, r.newError(name, v, fmt.Errorf("cannot be made into a float64: %w", err))
 	}
 
 	return f, nil
@@ -65,44 +102,40 @@ func (r *Row) GetDate(name string) (time.Time, error) {
 		return time.Time{}, err
 	}
 
-	t, err := parseDate(v)
+	t, err := parseDate(v, r.layouts)
 	if err != nil {
-		return time.Time{}, fmt.Errorf("%s is not a valid date", name)
+		return time.Time{}, r.newError(name, v, err)
 	}
 
 	return t, nil
 }
 
-func (r *Row) AsMap() map[string]string {
-	row := map[string]string{}
-	for f, ix := range r.fields {
-		row[f] = r.values[ix]
+// GetOptionalDate is like GetDate, but returns nil when the column is
+// blank.
+func (r *Row) GetOptionalDate(name string) (*time.Time, error) {
+	v, err := r.Get(name)
+	if err != nil {
+		return nil, err
 	}
-	return row
-}
 
-func parseDate(v string) (time.Time, error) {
-	vals := strings.SplitN(v, "/", 3)
-	if len(vals) != 3 {
-		return time.Time{}, fmt.Errorf("invalid date: %s", v)
+	if strings.TrimSpace(v) == "" {
+		return nil, nil
 	}
 
-	m, err := strconv.Atoi(vals[0])
+	t, err := parseDate(v, r.layouts)
 	if err != nil {
-		return time.Time{}, fmt.Errorf("invalid date: %s", v)
+		return nil, r.newError(name, v, err)
 	}
 
-	d, err := strconv.Atoi(vals[1])
-	if err != nil {
-		return time.Time{}, fmt.Errorf("invalid date: %s", v)
-	}
+	return &t, nil
+}
 
-	y, err := strconv.Atoi(vals[2])
-	if err != nil {
-		return time.Time{}, fmt.Errorf("invalid date: %s", v)
+func (r *Row) AsMap() map[string]string {
+	row := map[string]string{}
+	for f, ix := range r.fields {
+		row[f] = r.values[ix]
 	}
-
-	return time.Date(y, time.Month(m), d, 0, 0, 0, 0, time.UTC), nil
+	return row
 }
 
 func (r *Row) MustMarshal() []byte {
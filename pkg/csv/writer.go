@@ -0,0 +1,189 @@
+package csv
+
+import (
+	"encoding"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// DefaultWriteDateLayout is the layout used to write dates when a writer
+// hasn't been given one.
+const DefaultWriteDateLayout = "2006-01-02"
+
+var textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+
+// Writer encodes structs as CSV rows using the same csv tags that are used
+// for decoding.
+type Writer struct {
+	w      *csv.Writer
+	opts   Options
+	layout string
+	typ    reflect.Type
+	fields []*field
+}
+
+func NewWriter(w io.Writer, opts ...Option) *Writer {
+	var o Options
+	o.apply(opts)
+
+	cw := csv.NewWriter(w)
+	cw.Comma = o.delimiter
+
+	layout := DefaultWriteDateLayout
+	if len(o.layouts) > 0 {
+		layout = o.layouts[0]
+	}
+
+	return &Writer{
+		w:      cw,
+		opts:   o,
+		layout: layout,
+	}
+}
+
+func structOf(v interface{}) (reflect.Value, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return reflect.Value{}, errors.New("encode requires a non-nil value")
+		}
+		rv = rv.Elem()
+	}
+
+	if rv.Kind() != reflect.Struct {
+		return reflect.Value{}, fmt.Errorf("encode requires a struct, got %T", v)
+	}
+
+	return rv, nil
+}
+
+// WriteHeader writes the header for rows of the same type as v, a struct
+// or a pointer to a struct. Calling it is only necessary to get a header
+// when there may not be any rows; Encode writes the header otherwise.
+func (w *Writer) WriteHeader(v interface{}) error {
+	rv, err := structOf(v)
+	if err != nil {
+		return err
+	}
+
+	return w.ensureHeader(rv.Type())
+}
+
+// Encode writes v, a struct or a pointer to a struct, as a row. The header
+// is written before the first row and every row must be of the same type.
+func (w *Writer) Encode(v interface{}) error {
+	rv, err := structOf(v)
+	if err != nil {
+		return err
+	}
+
+	if err := w.ensureHeader(rv.Type()); err != nil {
+		return err
+	}
+
+	vals := make([]string, 0, len(w.fields))
+	for _, f := range w.fields {
+		fv, ok := f.value(rv)
+		if !ok {
+			vals = append(vals, "")
+			continue
+		}
+
+		s, err := formatValue(fv, w.layout)
+		if err != nil {
+			return fmt.Errorf("%s: %w", f.name, err)
+		}
+		vals = append(vals, s)
+	}
+
+	return w.w.Write(vals)
+}
+
+// Flush writes any buffered rows to the underlying writer.
+func (w *Writer) Flush() error {
+	w.w.Flush()
+	return w.w.Error()
+}
+
+func (w *Writer) ensureHeader(t reflect.Type) error {
+	if w.typ != nil {
+		if w.typ != t {
+			return fmt.Errorf("encode requires a %s, got %s", w.typ, t)
+		}
+		return nil
+	}
+
+	all := fieldsOf(t)
+
+	fields := all
+	if cols := w.opts.columns; len(cols) > 0 {
+		byColumn := map[string]*field{}
+		for _, f := range all {
+			byColumn[f.column] = f
+		}
+
+		fields = make([]*field, 0, len(cols))
+		for _, col := range cols {
+			f := byColumn[col]
+			if f == nil {
+				return fmt.Errorf("unknown column: %s", col)
+			}
+			fields = append(fields, f)
+		}
+	}
+
+	hdrs := make([]string, 0, len(fields))
+	for _, f := range fields {
+		hdrs = append(hdrs, f.column)
+	}
+
+	if err := w.w.Write(hdrs); err != nil {
+		return err
+	}
+
+	w.typ = t
+	w.fields = fields
+	return nil
+}
+
+func formatValue(v reflect.Value, layout string) (string, error) {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return "", nil
+		}
+		return formatValue(v.Elem(), layout)
+	}
+
+	// time.Time is a TextMarshaler, but dates are written with the layout.
+	if v.Type() == timeType {
+		return v.Interface().(time.Time).Format(layout), nil
+	}
+
+	if v.Type().Implements(textMarshalerType) {
+		b, err := v.Interface().(encoding.TextMarshaler).MarshalText()
+		if err != nil {
+			return "", err
+		}
+		return string(b), nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, v.Type().Bits()), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	}
+
+	return "", errors.New("unsupported type " + v.Type().String())
+}
@@ -0,0 +1,189 @@
+package csv
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+type testDirection string
+
+func (d testDirection) MarshalText() ([]byte, error) {
+	return []byte("dir:" + string(d)), nil
+}
+
+type testTerm struct {
+	Term int `csv:"term"`
+}
+
+type testRecord struct {
+	testTerm
+	ID        string        `csv:"caseId"`
+	Name      string        `csv:"caseName"`
+	Decided   time.Time     `csv:"dateDecision"`
+	Argued    *time.Time    `csv:"dateArgument"`
+	Votes     *int          `csv:"majVotes"`
+	Score     float64       `csv:"score"`
+	Unanimous bool          `csv:"unanimous"`
+	Direction testDirection `csv:"direction"`
+	Ignored   string        `csv:"-"`
+	Untagged  string
+}
+
+func newTestRecord() *testRecord {
+	argued := time.Date(1946, time.November, 20, 0, 0, 0, 0, time.UTC)
+	votes := 5
+	return &testRecord{
+		testTerm:  testTerm{Term: 1946},
+		ID:        "1946-001",
+		Name:      `Everson v. Board of Education of the Township of "Ewing", et al.`,
+		Decided:   time.Date(1947, time.February, 10, 0, 0, 0, 0, time.UTC),
+		Argued:    &argued,
+		Votes:     &votes,
+		Score:     -0.25,
+		Unanimous: false,
+		Direction: "L",
+		Ignored:   "ignored",
+		Untagged:  "untagged",
+	}
+}
+
+func writeTestRecords(t *testing.T, opts []Option, recs ...*testRecord) string {
+	var b strings.Builder
+	w := NewWriter(&b, opts...)
+	if err := w.WriteHeader(&testRecord{}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, rec := range recs {
+		if err := w.Encode(rec); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	return b.String()
+}
+
+func TestWriter(t *testing.T) {
+	blank := &testRecord{Name: "Marbury v. Madison, 5 U.S. 137"}
+
+	tests := []struct {
+		name     string
+		opts     []Option
+		recs     []*testRecord
+		expected string
+	}{
+		{
+			name: "all columns",
+			recs: []*testRecord{newTestRecord()},
+			expected: "term,caseId,caseName,dateDecision,dateArgument,majVotes,score,unanimous,direction\n" +
+				`1946,1946-001,"Everson v. Board of Education of the Township of ""Ewing"", et al.",1947-02-10,1946-11-20,5,-0.25,false,dir:L` + "\n",
+		},
+		{
+			name:     "header only",
+			expected: "term,caseId,caseName,dateDecision,dateArgument,majVotes,score,unanimous,direction\n",
+		},
+		{
+			name: "selected columns",
+			opts: []Option{WithColumns("direction", "caseId", "term")},
+			recs: []*testRecord{newTestRecord()},
+			expected: "direction,caseId,term\n" +
+				"dir:L,1946-001,1946\n",
+		},
+		{
+			name: "date layout",
+			opts: []Option{
+				WithColumns("dateDecision", "dateArgument"),
+				WithDateLayouts("1/2/2006", "2006-01-02"),
+			},
+			recs: []*testRecord{newTestRecord()},
+			expected: "dateDecision,dateArgument\n" +
+				"2/10/1947,11/20/1946\n",
+		},
+		{
+			name: "nil pointers and commas",
+			opts: []Option{WithColumns("caseName", "dateArgument", "majVotes")},
+			recs: []*testRecord{blank},
+			expected: "caseName,dateArgument,majVotes\n" +
+				`"Marbury v. Madison, 5 U.S. 137",,` + "\n",
+		},
+		{
+			name: "delimiter",
+			opts: []Option{WithColumns("caseId", "caseName"), WithDelimiter('\t')},
+			recs: []*testRecord{blank},
+			expected: "caseId\tcaseName\n" +
+				"\tMarbury v. Madison, 5 U.S. 137\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if s := writeTestRecords(t, test.opts, test.recs...); s != test.expected {
+				t.Fatalf("expected:\n%s\ngot:\n%s", test.expected, s)
+			}
+		})
+	}
+}
+
+func TestWriterErrors(t *testing.T) {
+	var b strings.Builder
+
+	w := NewWriter(&b, WithColumns("caseId", "overruled"))
+	if err := w.Encode(newTestRecord()); err == nil {
+		t.Fatal("expected an error for an unknown column")
+	}
+
+	w = NewWriter(&b)
+	if err := w.Encode(newTestRecord()); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Encode(&testTerm{Term: 1946}); err == nil {
+		t.Fatal("expected an error for a different type")
+	}
+
+	w = NewWriter(&b)
+	if err := w.Encode("1946-001"); err == nil {
+		t.Fatal("expected an error for a string")
+	}
+
+	var rec *testRecord
+	if err := w.Encode(rec); err == nil {
+		t.Fatal("expected an error for a nil struct")
+	}
+}
+
+func TestWriterRoundTrip(t *testing.T) {
+	src := writeTestRecords(
+		t,
+		[]Option{WithColumns("term", "caseId", "caseName", "dateDecision", "majVotes")},
+		newTestRecord())
+
+	r, err := NewReader(strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var rec struct {
+		Term    int       `csv:"term"`
+		ID      string    `csv:"caseId"`
+		Name    string    `csv:"caseName"`
+		Decided time.Time `csv:"dateDecision"`
+		Votes   *int      `csv:"majVotes"`
+	}
+	if err := r.Decode(&rec); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := newTestRecord()
+	if rec.Term != expected.Term ||
+		rec.ID != expected.ID ||
+		rec.Name != expected.Name ||
+		!rec.Decided.Equal(expected.Decided) ||
+		rec.Votes == nil || *rec.Votes != *expected.Votes {
+		t.Fatalf("expected %+v, got %+v", expected, rec)
+	}
+}
@@ -0,0 +1,151 @@
+package data
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/kellegous/scotus/pkg/csv"
+	"github.com/kellegous/scotus/pkg/data/martinquinn/bycourt"
+	"github.com/kellegous/scotus/pkg/data/martinquinn/byjustice"
+	"github.com/kellegous/scotus/pkg/data/scotusdb"
+)
+
+// ErrUnknownTable is returned when exporting a table that doesn't exist.
+var ErrUnknownTable = errors.New("unknown table")
+
+// Tables are the names of the tables that can be exported from a model.
+var Tables = []string{
+	"cases",
+	"votes",
+	"martinquinn-courts",
+	"martinquinn-justices",
+	"overrulings",
+}
+
+type caseRecord struct {
+	Term int `csv:"term"`
+	*scotusdb.Case
+}
+
+type voteRecord struct {
+	CaseID string `csv:"caseId"`
+	*scotusdb.Vote
+}
+
+type courtRecord struct {
+	Term int `csv:"term"`
+	*bycourt.Stats
+}
+
+type justiceRecord struct {
+	Term int `csv:"term"`
+	*byjustice.Justice
+}
+
+type overrulingRecord struct {
+	Name          string `csv:"name"`
+	URL           string `csv:"url"`
+	Year          int    `csv:"year"`
+	OverruledName string `csv:"overruledName"`
+	OverruledURL  string `csv:"overruledUrl"`
+	OverruledYear int    `csv:"overruledYear"`
+}
+
+// WriteCSV writes one of the Tables as CSV to w. Options such as
+// csv.WithColumns can be used to select the columns.
+func (m *Model) WriteCSV(
+	w io.Writer,
+	table string,
+	opts ...csv.Option,
+) error {
+	cw := csv.NewWriter(w, opts...)
+	if err := m.encodeTable(cw, table); err != nil {
+		return err
+	}
+	return cw.Flush()
+}
+
+func (m *Model) encodeTable(cw *csv.Writer, table string) error {
+	switch table {
+	case "cases":
+		if err := cw.WriteHeader(&caseRecord{}); err != nil {
+			return err
+		}
+		for _, t := range m.SCOTUSDBCases {
+			for _, c := range t.Cases {
+				if err := cw.Encode(&caseRecord{
+					Term: t.Year,
+					Case: c,
+				}); err != nil {
+					return err
+				}
+			}
+		}
+	case "votes":
+		if err := cw.WriteHeader(&voteRecord{}); err != nil {
+			return err
+		}
+		for _, t := range m.SCOTUSDBCases {
+			for _, c := range t.Cases {
+				for _, v := range c.Votes {
+					if err := cw.Encode(&voteRecord{
+						CaseID: c.ID,
+						Vote:   v,
+					}); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	case "martinquinn-courts":
+		if err := cw.WriteHeader(&courtRecord{}); err != nil {
+			return err
+		}
+		for _, c := range m.MartinQuinnByYear {
+			for _, s := range c.Stats {
+				if err := cw.Encode(&courtRecord{
+					Term:  c.Year,
+					Stats: s,
+				}); err != nil {
+					return err
+				}
+			}
+		}
+	case "martinquinn-justices":
+		if err := cw.WriteHeader(&justiceRecord{}); err != nil {
+			return err
+		}
+		for _, t := range m.MartinQuinnByJustice {
+			for _, j := range t.Justices {
+				if err := cw.Encode(&justiceRecord{
+					Term:    t.Year,
+					Justice: j,
+				}); err != nil {
+					return err
+				}
+			}
+		}
+	case "overrulings":
+		if err := cw.WriteHeader(&overrulingRecord{}); err != nil {
+			return err
+		}
+		for _, d := range m.Overrulings {
+			for _, c := range d.Overruled {
+				if err := cw.Encode(&overrulingRecord{
+					Name:          d.Name,
+					URL:           d.URL,
+					Year:          d.Year,
+					OverruledName: c.Name,
+					OverruledURL:  c.URL,
+					OverruledYear: c.Year,
+				}); err != nil {
+					return err
+				}
+			}
+		}
+	default:
+		return fmt.Errorf("%w: %s", ErrUnknownTable, table)
+	}
+	return nil
+}
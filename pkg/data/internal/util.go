@@ -6,6 +6,11 @@ import (
 	"io"
 	"net/http"
 	"os"
+
+	"github.com/kellegous/scotus/pkg/csv"
+	"github.com/kellegous/scotus/pkg/logging"
+
+	"go.uber.org/zap"
 )
 
 func EnsureDownload(
@@ -49,3 +54,17 @@ func EnsureDownload(
 
 	return nil
 }
+
+// LogSkipped logs each of the rows that a lenient reader skipped in src.
+func LogSkipped(
+	ctx context.Context,
+	src string,
+	cr *csv.Reader,
+) {
+	lg := logging.L(ctx).Named("data")
+	for _, err := range cr.Skipped() {
+		lg.Warn("skipped malformed row",
+			zap.String("src", src),
+			zap.Error(err))
+	}
+}
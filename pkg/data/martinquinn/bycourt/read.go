@@ -44,15 +44,22 @@ func Read(
 	}
 	defer r.Close()
 
-	return read(r)
-}
+	cr, err := csv.NewReader(r, o.CSVOptions()...)
+	if err != nil {
+		return nil, err
+	}
 
-func read(r io.Reader) ([]*Court, error) {
-	cr, err := csv.NewReader(r)
+	courts, err := read(cr)
 	if err != nil {
 		return nil, err
 	}
 
+	internal.LogSkipped(ctx, src, cr)
+
+	return courts, nil
+}
+
+func read(cr *csv.Reader) ([]*Court, error) {
 	byYear := map[int]*Court{}
 	var courts []*Court
 
@@ -74,7 +81,18 @@ func read(r io.Reader) ([]*Court, error) {
 				return strconv.Atoi(s[:4])
 			})
 		if err != nil {
-			return nil, err
+			if err := cr.Skip(err); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		stats, err := parseStats(row)
+		if err != nil {
+			if err := cr.Skip(err); err != nil {
+				return nil, err
+			}
+			continue
 		}
 
 		court := byYear[year]
@@ -86,11 +104,6 @@ func read(r io.Reader) ([]*Court, error) {
 			courts = append(courts, court)
 		}
 
-		stats, err := parseStats(row)
-		if err != nil {
-			return nil, err
-		}
-
 		court.Stats = append(court.Stats, stats)
 	}
 
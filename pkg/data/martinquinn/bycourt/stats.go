@@ -3,44 +3,17 @@ package bycourt
 import "github.com/kellegous/scotus/pkg/csv"
 
 type Stats struct {
-	MedianJusticeScore    float64
-	StdDevOfMedianJustice float64
-	MinJusticeScore       float64
-	MaxJusticeScore       float64
-	MedianJustice         string
+	MedianJusticeScore    float64 `csv:"med"`
+	StdDevOfMedianJustice float64 `csv:"med_sd"`
+	MinJusticeScore       float64 `csv:"min"`
+	MaxJusticeScore       float64 `csv:"max"`
+	MedianJustice         string  `csv:"justice"`
 }
 
 func parseStats(row *csv.Row) (*Stats, error) {
-	med, err := row.GetFloat64("med", csv.ParseFloat64)
-	if err != nil {
+	var s Stats
+	if err := row.Decode(&s); err != nil {
 		return nil, err
 	}
-
-	std, err := row.GetFloat64("med_sd", csv.ParseFloat64)
-	if err != nil {
-		return nil, err
-	}
-
-	min, err := row.GetFloat64("min", csv.ParseFloat64)
-	if err != nil {
-		return nil, err
-	}
-
-	max, err := row.GetFloat64("max", csv.ParseFloat64)
-	if err != nil {
-		return nil, err
-	}
-
-	jus, err := row.Get("justice")
-	if err != nil {
-		return nil, err
-	}
-
-	return &Stats{
-		MedianJusticeScore:    med,
-		StdDevOfMedianJustice: std,
-		MinJusticeScore:       min,
-		MaxJusticeScore:       max,
-		MedianJustice:         jus,
-	}, nil
+	return &s, nil
 }
@@ -43,15 +43,22 @@ func Read(
 	}
 	defer r.Close()
 
-	return read(r)
-}
+	cr, err := csv.NewReader(r, o.CSVOptions()...)
+	if err != nil {
+		return nil, err
+	}
 
-func read(r io.Reader) ([]*Term, error) {
-	cr, err := csv.NewReader(r)
+	terms, err := read(cr)
 	if err != nil {
 		return nil, err
 	}
 
+	internal.LogSkipped(ctx, src, cr)
+
+	return terms, nil
+}
+
+func read(cr *csv.Reader) ([]*Term, error) {
 	byYear := map[int]*Term{}
 	var terms []*Term
 	for {
@@ -64,7 +71,18 @@ func read(r io.Reader) ([]*Term, error) {
 
 		year, err := row.GetInt("term", strconv.Atoi)
 		if err != nil {
-			return nil, err
+			if err := cr.Skip(err); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		justice, err := parseJustice(row)
+		if err != nil {
+			if err := cr.Skip(err); err != nil {
+				return nil, err
+			}
+			continue
 		}
 
 		term := byYear[year]
@@ -74,11 +92,6 @@ func read(r io.Reader) ([]*Term, error) {
 			terms = append(terms, term)
 		}
 
-		justice, err := parseJustice(row)
-		if err != nil {
-			return nil, err
-		}
-
 		term.Justices = append(term.Justices, justice)
 	}
 
@@ -2,56 +2,110 @@ package data
 
 import (
 	"context"
+	"time"
 
 	"github.com/kellegous/scotus/pkg/async"
 	"github.com/kellegous/scotus/pkg/data/martinquinn/bycourt"
+	"github.com/kellegous/scotus/pkg/data/martinquinn/byjustice"
 	"github.com/kellegous/scotus/pkg/data/option"
 	"github.com/kellegous/scotus/pkg/data/overrulings"
 	"github.com/kellegous/scotus/pkg/data/scotusdb"
+	"github.com/kellegous/scotus/pkg/logging"
+
+	"go.uber.org/zap"
 )
 
+// maxSkippedRows is the number of malformed rows that will be tolerated
+// in each of the CSV sources, so a single bad row in an upstream release
+// doesn't keep the model from loading.
+const maxSkippedRows = 100
+
 type Model struct {
-	Overrulings       []*overrulings.Decision
-	MartinQuinnByYear []*bycourt.Court
-	SCOTUSDBCases     []*scotusdb.Term
+	Overrulings          []*overrulings.Decision
+	MartinQuinnByYear    []*bycourt.Court
+	MartinQuinnByJustice []*byjustice.Term
+	SCOTUSDBCases        []*scotusdb.Term
 }
 
+// LoadModel loads the model from the snapshot in dataDir when it is
+// still current with the source files, otherwise it reads all of the
+// sources and writes a fresh snapshot.
 func LoadModel(
 	ctx context.Context,
 	dataDir string,
 ) (*Model, error) {
-	fa := async.Run(
-		func() ([]*overrulings.Decision, error) {
-			return overrulings.Read(ctx, option.WithDataDir(dataDir))
-		},
-		nil)
-
-	fb := async.Run(
-		func() ([]*bycourt.Court, error) {
-			return bycourt.Read(ctx, option.WithDataDir(dataDir))
-		},
-		nil)
-
-	fc := async.Run(
-		func() ([]*scotusdb.Term, error) {
-			return scotusdb.Read(ctx, scotusdb.WithDataDir(dataDir))
-		},
-		nil)
+	lg := logging.L(ctx).Named("data")
 
-	m := &Model{}
-	var err error
-	m.Overrulings, err = fa.Resolve()
-	if err != nil {
-		return nil, err
+	start := time.Now()
+	m, err := ReadSnapshot(dataDir)
+	if err == nil {
+		lg.Info("model loaded from snapshot",
+			zap.Duration("duration", time.Since(start)))
+		return m, nil
+	} else if isStaleSnapshot(err) {
+		lg.Info("model snapshot not usable",
+			zap.Error(err))
+	} else {
+		lg.Warn("unable to read model snapshot",
+			zap.Error(err))
 	}
 
-	m.MartinQuinnByYear, err = fb.Resolve()
+	start = time.Now()
+	m, err = loadModel(ctx, dataDir)
 	if err != nil {
 		return nil, err
 	}
+	lg.Info("model loaded from sources",
+		zap.Duration("duration", time.Since(start)))
 
-	m.SCOTUSDBCases, err = fc.Resolve()
-	if err != nil {
+	if err := WriteSnapshot(dataDir, m); err != nil {
+		lg.Warn("unable to write model snapshot",
+			zap.Error(err))
+	}
+
+	return m, nil
+}
+
+func loadModel(
+	ctx context.Context,
+	dataDir string,
+) (*Model, error) {
+	m := &Model{}
+
+	// every dataset is loaded even when one fails so that the error names
+	// all of the datasets that need attention.
+	var g async.Group
+
+	g.Go("overrulings", func() (err error) {
+		m.Overrulings, err = overrulings.Read(ctx, option.WithDataDir(dataDir))
+		return err
+	})
+
+	g.Go("martin-quinn by court", func() (err error) {
+		m.MartinQuinnByYear, err = bycourt.Read(
+			ctx,
+			option.WithDataDir(dataDir),
+			option.WithMaxSkippedRows(maxSkippedRows))
+		return err
+	})
+
+	g.Go("martin-quinn by justice", func() (err error) {
+		m.MartinQuinnByJustice, err = byjustice.Read(
+			ctx,
+			option.WithDataDir(dataDir),
+			option.WithMaxSkippedRows(maxSkippedRows))
+		return err
+	})
+
+	g.Go("scotusdb", func() (err error) {
+		m.SCOTUSDBCases, err = scotusdb.Read(
+			ctx,
+			scotusdb.WithDataDir(dataDir),
+			scotusdb.WithMaxSkippedRows(maxSkippedRows))
+		return err
+	})
+
+	if err := g.Wait(); err != nil {
 		return nil, err
 	}
 
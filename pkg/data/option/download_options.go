@@ -1,15 +1,20 @@
 package option
 
-import "net/http"
+import (
+	"net/http"
+
+	"github.com/kellegous/scotus/pkg/csv"
+)
 
 const (
 	DefaultDataDir = "data"
 )
 
 type DownloadOptions struct {
-	URL     string
-	DataDir string
-	Client  *http.Client
+	URL            string
+	DataDir        string
+	Client         *http.Client
+	MaxSkippedRows int
 }
 
 func (o *DownloadOptions) ApplyOptions(
@@ -26,6 +31,14 @@ func (o *DownloadOptions) ApplyOptions(
 	}
 }
 
+// CSVOptions returns the options for reading a downloaded CSV file.
+func (o *DownloadOptions) CSVOptions() []csv.Option {
+	if o.MaxSkippedRows > 0 {
+		return []csv.Option{csv.Lenient(o.MaxSkippedRows)}
+	}
+	return nil
+}
+
 type DownloadOption func(o *DownloadOptions)
 
 func FromURL(url string) DownloadOption {
@@ -45,3 +58,11 @@ func WithHTTPClient(client *http.Client) DownloadOption {
 		o.Client = client
 	}
 }
+
+// WithMaxSkippedRows allows up to n malformed rows to be skipped when
+// reading a CSV file instead of failing on the first one.
+func WithMaxSkippedRows(n int) DownloadOption {
+	return func(o *DownloadOptions) {
+		o.MaxSkippedRows = n
+	}
+}
@@ -3,7 +3,6 @@ package overrulings
 import (
 	"bytes"
 	"context"
-	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -22,7 +21,10 @@ const (
 	DefaultURL   = "https://constitution.congress.gov/resources/decisions-overruled/"
 )
 
-var yearPattern = regexp.MustCompile(`\((\d{4})\)`)
+var (
+	yearPattern   = regexp.MustCompile(`\((\d{4})\)`)
+	tableSelector = search.MustCompile("table")
+)
 
 func Read(
 	ctx context.Context,
@@ -45,38 +47,6 @@ func Read(
 	return read(src)
 }
 
-func parseYear(td *html.Node) (int, error) {
-	if c := td.FirstChild; c != nil && c.Type == html.TextNode {
-		return strconv.Atoi(c.Data)
-	}
-
-	return 0, errors.New("cell doesn't include a single text node")
-}
-
-func innerTextOf(n *html.Node) string {
-	var buf bytes.Buffer
-	for c := n.FirstChild; c != nil; c = c.NextSibling {
-		if c.Type == html.TextNode {
-			buf.WriteString(c.Data)
-		} else if c.Type == html.ElementNode && c.Data == "b" {
-			buf.WriteByte(' ')
-		}
-	}
-	return buf.String()
-}
-
-func getAttribute(
-	n *html.Node,
-	name string,
-) string {
-	for _, attr := range n.Attr {
-		if attr.Key == name {
-			return attr.Val
-		}
-	}
-	return ""
-}
-
 func parseCaseText(n *html.Node) (string, *html.Node) {
 	var buf bytes.Buffer
 	for ; n != nil; n = n.NextSibling {
@@ -105,11 +75,15 @@ func extractYear(name string) (int, error) {
 
 func parseCases(td *html.Node) ([]*Case, error) {
 	var cases []*Case
+	if td == nil {
+		return cases, nil
+	}
+
 	for c := td.FirstChild; c != nil; c = c.NextSibling {
 		if c.Type == html.ElementNode && c.Data == "a" {
 			cases = append(cases, &Case{
-				Name: innerTextOf(c),
-				URL:  getAttribute(c, "href"),
+				Name: search.InnerText(c),
+				URL:  hrefOf(c),
 			})
 		} else {
 			s, n := parseCaseText(c)
@@ -127,20 +101,66 @@ func parseCases(td *html.Node) ([]*Case, error) {
 	return cases, nil
 }
 
-func parseDecision(tds []*html.Node) (*Decision, error) {
-	c, err := parseCases(tds[1])
+func hrefOf(a *html.Node) string {
+	if attr := search.GetAttr(a, "href"); attr != nil {
+		return attr.Val
+	}
+	return ""
+}
+
+// columns holds the headers of the columns that hold each part of a
+// decision.
+type columns struct {
+	overruling string
+	year       string
+	overruled  string
+}
+
+// findColumns finds the overruling, overruled and year columns of one of
+// the tables on the overrulings page.
+func findColumns(t *search.Table) (*columns, error) {
+	var cols columns
+	var err error
+
+	cols.year, err = t.FindHeader("year")
+	if err != nil {
+		return nil, err
+	}
+
+	cols.overruling, err = t.FindHeader("overruling")
+	if err != nil {
+		return nil, err
+	}
+
+	// the header of the year column, e.g. "Year overruled", would also
+	// match the overruled column, so it is excluded here.
+	for _, h := range t.Headers {
+		if h != cols.year && strings.Contains(strings.ToLower(h), "overruled") {
+			cols.overruled = h
+			break
+		}
+	}
+	if cols.overruled == "" {
+		return nil, fmt.Errorf("no column for overruled cases in %q", t.Headers)
+	}
+
+	return &cols, nil
+}
+
+func parseDecision(cols *columns, rec search.Record) (*Decision, error) {
+	c, err := parseCases(rec.Get(cols.overruling).Node)
 	if err != nil {
 		return nil, err
 	} else if len(c) != 1 {
 		return nil, fmt.Errorf("expected a single case, found %d", len(c))
 	}
 
-	c[0].Year, err = parseYear(tds[2])
+	c[0].Year, err = strconv.Atoi(rec.Get(cols.year).Text)
 	if err != nil {
 		return nil, err
 	}
 
-	cases, err := parseCases(tds[3])
+	cases, err := parseCases(rec.Get(cols.overruled).Node)
 	if err != nil {
 		return nil, err
 	}
@@ -160,32 +180,26 @@ func parseDecision(tds []*html.Node) (*Decision, error) {
 }
 
 func extract(doc *html.Node) ([]*Decision, error) {
-	// selector "table tbody tr"
-	trs := search.Query(
-		doc,
-		search.HasAll(
-			search.IsElementOf("tr"),
-			search.HasParent(
-				search.HasAll(
-					search.IsElementOf("tbody"),
-					search.HasParent(
-						search.IsElementOf("table"),
-					),
-				),
-			),
-		),
-	)
-
-	decisions := make([]*Decision, 0, len(trs))
-
-	for _, tr := range trs {
-		tds := search.Query(tr, search.IsElementOf("td"))
-		decision, err := parseDecision(tds)
+	var decisions []*Decision
+	for _, n := range search.QueryScoped(doc, tableSelector) {
+		t, err := search.ReadTable(n)
 		if err != nil {
 			return nil, err
 		}
 
-		decisions = append(decisions, decision)
+		cols, err := findColumns(t)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, rec := range t.Rows {
+			decision, err := parseDecision(cols, rec)
+			if err != nil {
+				return nil, err
+			}
+
+			decisions = append(decisions, decision)
+		}
 	}
 
 	return decisions, nil
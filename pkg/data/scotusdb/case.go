@@ -1,77 +1,62 @@
 package scotusdb
 
 import (
-	"fmt"
-	"strconv"
 	"time"
 
 	"github.com/kellegous/scotus/pkg/csv"
 )
 
 type Case struct {
-	ID            string    `json:"id"`
-	Name          string    `json:"name"`
-	MajorityVotes int       `json:"majority-votes"`
-	MinorityVotes int       `json:"minority-votes"`
-	DecisionDate  time.Time `json:"decision-date"`
-	Votes         []*Vote   `json:"votes"`
-	Chief         string    `json:"chief"`
+	ID             string     `json:"id" csv:"caseId"`
+	Name           string     `json:"name" csv:"caseName"`
+	Docket         string     `json:"docket" csv:"docket"`
+	MajorityVotes  int        `json:"majority-votes" csv:"majVotes"`
+	MinorityVotes  int        `json:"minority-votes" csv:"minVotes"`
+	DecisionDate   time.Time  `json:"decision-date" csv:"dateDecision"`
+	ArgumentDate   *time.Time `json:"argument-date,omitempty" csv:"dateArgument"`
+	ReargumentDate *time.Time `json:"reargument-date,omitempty" csv:"dateRearg"`
+	Votes          []*Vote    `json:"votes"`
+	Chief          string     `json:"chief" csv:"chief"`
 }
 
-func parseInt(s string) (int, error) {
-	if s == "" {
-		return -1, nil
+// caseRow is the case portion of a justice-centered row.
+type caseRow struct {
+	Name         string     `csv:"caseName"`
+	Docket       string     `csv:"docket"`
+	MajVotes     *int       `csv:"majVotes"`
+	MinVotes     *int       `csv:"minVotes"`
+	DateDecision time.Time  `csv:"dateDecision"`
+	DateArgument *time.Time `csv:"dateArgument"`
+	DateRearg    *time.Time `csv:"dateRearg"`
+	Chief        string     `csv:"chief"`
+}
+
+// votesOrUnknown returns the vote count or -1 if the count is unknown.
+func votesOrUnknown(n *int) int {
+	if n == nil {
+		return -1
 	}
-	return strconv.Atoi(s)
+	return *n
 }
 
 func readCase(
-	cases map[string]*Case,
+	id string,
 	row *csv.Row,
-) (*Case, bool, error) {
-	id, err := row.Get("caseId")
-	if err != nil {
-		return nil, false, err
-	}
-
-	if c := cases[id]; c != nil {
-		return c, false, nil
-	}
-
-	name, err := row.Get("caseName")
-	if err != nil {
-		return nil, false, err
-	}
-
-	majVotes, err := row.GetInt("majVotes", parseInt)
-	if err != nil {
-		return nil, false, fmt.Errorf("%s: %w", name, err)
-	}
-
-	minVotes, err := row.GetInt("minVotes", parseInt)
-	if err != nil {
-		return nil, false, fmt.Errorf("%s: %w", name, err)
-	}
-
-	descDate, err := row.GetDate("dateDecision")
-	if err != nil {
-		return nil, false, err
-	}
-
-	chief, err := row.Get("chief")
-	if err != nil {
-		return nil, false, err
-	}
-
-	c := &Case{
-		ID:            id,
-		Name:          name,
-		Chief:         chief,
-		MajorityVotes: majVotes,
-		MinorityVotes: minVotes,
-		DecisionDate:  descDate,
-	}
-	cases[id] = c
-
-	return c, true, nil
+) (*Case, error) {
+	var cr caseRow
+	if err := row.Decode(&cr); err != nil {
+		return nil, err
+	}
+
+	return &Case{
+		ID:             id,
+		Name:           cr.Name,
+		Docket:         cr.Docket,
+		Chief:          cr.Chief,
+		MajorityVotes:  votesOrUnknown(cr.MajVotes),
+		MinorityVotes:  votesOrUnknown(cr.MinVotes),
+		DecisionDate:   cr.DateDecision,
+		ArgumentDate:   cr.DateArgument,
+		ReargumentDate: cr.DateRearg,
+	}, nil
 }
@@ -39,6 +39,21 @@ func (d *Decision) UnmarshalJSON(b []byte) error {
 	return fmt.Errorf("invalid decision: %s", s)
 }
 
+func (d Decision) MarshalText() ([]byte, error) {
+	return []byte{byte(d)}, nil
+}
+
+// UnmarshalText accepts both the SCDB coding of a decision and the
+// symbols used by Decision itself.
+func (d *Decision) UnmarshalText(b []byte) error {
+	dec, err := decisionFromString(string(b))
+	if err != nil {
+		return err
+	}
+	*d = dec
+	return nil
+}
+
 const (
 	Abstained       Decision = 'x'
 	AgainstMajority Decision = '-'
@@ -47,11 +62,11 @@ const (
 
 func decisionFromString(v string) (Decision, error) {
 	switch v {
-	case "1":
+	case "1", string(AgainstMajority):
 		return AgainstMajority, nil
-	case "2":
+	case "2", string(WithMajority):
 		return WithMajority, nil
-	case "":
+	case "", string(Abstained):
 		return Abstained, nil
 	}
 	return Abstained, fmt.Errorf("invalid decision \"%s\"", v)
@@ -0,0 +1,69 @@
+package scotusdb
+
+import "sort"
+
+// mergeTerms combines the terms from several releases into a single list
+// ordered by year. Terms that appear in more than one release are merged
+// and the cases in every term are put into a stable order. A case that
+// appears in more than one release is kept from the first one it is in and
+// its id is returned among the duplicates, in sorted order.
+func mergeTerms(releases ...[]*Term) ([]*Term, []string) {
+	byYear := map[int]*Term{}
+	seen := map[string]bool{}
+	var dups []string
+	var terms []*Term
+
+	for _, release := range releases {
+		// cases in a single release are already unique, so only check
+		// against the cases from previous releases.
+		var ids []string
+		for _, t := range release {
+			merged := byYear[t.Year]
+			if merged == nil {
+				merged = &Term{Year: t.Year}
+				byYear[t.Year] = merged
+				terms = append(terms, merged)
+			}
+
+			for _, c := range t.Cases {
+				if seen[c.ID] {
+					dups = append(dups, c.ID)
+					continue
+				}
+				ids = append(ids, c.ID)
+				merged.Cases = append(merged.Cases, c)
+			}
+		}
+
+		for _, id := range ids {
+			seen[id] = true
+		}
+	}
+
+	sort.Strings(dups)
+
+	sort.Slice(terms, func(i, j int) bool {
+		return terms[i].Year < terms[j].Year
+	})
+
+	for _, t := range terms {
+		sortCases(t.Cases)
+	}
+
+	return terms, dups
+}
+
+// sortCases orders cases by decision date then docket, falling back to the
+// case id since dockets are often missing from the legacy release.
+func sortCases(cases []*Case) {
+	sort.Slice(cases, func(i, j int) bool {
+		a, b := cases[i], cases[j]
+		if !a.DecisionDate.Equal(b.DecisionDate) {
+			return a.DecisionDate.Before(b.DecisionDate)
+		}
+		if a.Docket != b.Docket {
+			return a.Docket < b.Docket
+		}
+		return a.ID < b.ID
+	})
+}
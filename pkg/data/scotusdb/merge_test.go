@@ -0,0 +1,101 @@
+package scotusdb
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func newCase(id, name string, decided string) *Case {
+	t, err := time.Parse("2006-01-02", decided)
+	if err != nil {
+		panic(err)
+	}
+	return &Case{
+		ID:           id,
+		Name:         name,
+		DecisionDate: t,
+	}
+}
+
+func TestMergeTerms(t *testing.T) {
+	legacy := []*Term{
+		{
+			Year: 1945,
+			Cases: []*Case{
+				newCase("1945-002", "Girouard v. United States", "1946-04-22"),
+				newCase("1945-001", "Marsh v. Alabama", "1946-01-07"),
+			},
+		},
+	}
+
+	// the modern release starts in 1946, but repeats a case from the
+	// legacy release with a different name.
+	modern := []*Term{
+		{
+			Year: 1946,
+			Cases: []*Case{
+				newCase("1946-001", "Everson v. Board of Education", "1947-02-10"),
+			},
+		},
+		{
+			Year: 1945,
+			Cases: []*Case{
+				newCase("1945-001", "MARSH v. ALABAMA", "1946-01-07"),
+				newCase("1945-003", "Colegrove v. Green", "1946-06-10"),
+			},
+		},
+	}
+
+	terms, dups := mergeTerms(legacy, modern)
+
+	if !reflect.DeepEqual(dups, []string{"1945-001"}) {
+		t.Fatalf("expected duplicates [1945-001], got %q", dups)
+	}
+
+	var years []int
+	names := map[int][]string{}
+	for _, term := range terms {
+		years = append(years, term.Year)
+		for _, c := range term.Cases {
+			names[term.Year] = append(names[term.Year], c.Name)
+		}
+	}
+
+	if !reflect.DeepEqual(years, []int{1945, 1946}) {
+		t.Fatalf("expected years [1945 1946], got %v", years)
+	}
+
+	expected := map[int][]string{
+		1945: {
+			"Marsh v. Alabama",
+			"Girouard v. United States",
+			"Colegrove v. Green",
+		},
+		1946: {
+			"Everson v. Board of Education",
+		},
+	}
+	if !reflect.DeepEqual(names, expected) {
+		t.Fatalf("expected %q, got %q", expected, names)
+	}
+}
+
+func TestMergeTermsWithoutDuplicates(t *testing.T) {
+	terms, dups := mergeTerms([]*Term{
+		{
+			Year: 2020,
+			Cases: []*Case{
+				newCase("2020-001", "Fulton v. Philadelphia", "2021-06-17"),
+			},
+		},
+	})
+
+	if len(dups) != 0 {
+		t.Fatalf("expected no duplicates, got %q", dups)
+	}
+
+	if len(terms) != 1 || len(terms[0].Cases) != 1 {
+		t.Fatalf("expected a single term with a single case, got %v", terms)
+	}
+}
@@ -1,7 +1,13 @@
 package scotusdb
 
 import (
+	"fmt"
 	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kellegous/scotus/pkg/csv"
 )
 
 const (
@@ -11,25 +17,86 @@ const (
 	DefaultDataDir        = "data"
 )
 
+// DefaultCacheDir is where ReadRelease keeps the releases it downloads.
+// They are kept out of the data directory since every file there is taken
+// to be a source of the model.
+func DefaultCacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+	return filepath.Join(dir, "scotus", "releases")
+}
+
+// Encoding is the character encoding of a release.
+type Encoding int
+
+const (
+	// Latin1 is the encoding of every SCDB release published so far.
+	Latin1 Encoding = iota
+	UTF8
+)
+
+func (e Encoding) String() string {
+	switch e {
+	case Latin1:
+		return "latin1"
+	case UTF8:
+		return "utf-8"
+	}
+	return fmt.Sprintf("Encoding(%d)", int(e))
+}
+
+// ParseEncoding parses the name of an encoding, as returned by String.
+func ParseEncoding(s string) (Encoding, error) {
+	switch strings.ToLower(s) {
+	case "latin1", "latin-1", "iso-8859-1":
+		return Latin1, nil
+	case "utf8", "utf-8":
+		return UTF8, nil
+	}
+	return 0, fmt.Errorf("unknown encoding: %s", s)
+}
+
 type Options struct {
 	legacyCasesURL string
 	modernCasesURL string
 	ot21CasesURL   string
+	legacyEncoding Encoding
+	modernEncoding Encoding
+	encoding       Encoding
 	dataDir        string
+	cacheDir       string
 	client         *http.Client
+	maxSkippedRows int
 }
 
 func (o *Options) apply(opts []Option) {
 	o.legacyCasesURL = DefaultLegacyCasesURL
 	o.modernCasesURL = DefaultModernCasesURL
 	o.ot21CasesURL = DefaultOT21CasesURL
+	o.legacyEncoding = Latin1
+	o.modernEncoding = Latin1
+	o.encoding = Latin1
 	o.dataDir = DefaultDataDir
+	o.cacheDir = DefaultCacheDir()
 	o.client = http.DefaultClient
 	for _, opt := range opts {
 		opt(o)
 	}
 }
 
+func (o *Options) csvOptions(enc Encoding) []csv.Option {
+	opts := []csv.Option{csv.StripBOM()}
+	if enc == Latin1 {
+		opts = append(opts, csv.Latin1())
+	}
+	if o.maxSkippedRows > 0 {
+		opts = append(opts, csv.Lenient(o.maxSkippedRows))
+	}
+	return opts
+}
+
 type Option func(o *Options)
 
 func WithCaseURLs(
@@ -44,14 +111,51 @@ func WithCaseURLs(
 	}
 }
 
+// WithCaseEncodings sets the encodings of the modern and legacy releases.
+// This is needed when WithCaseURLs points at releases that are not
+// encoded as Latin-1.
+func WithCaseEncodings(
+	modernEncoding Encoding,
+	legacyEncoding Encoding,
+) Option {
+	return func(o *Options) {
+		o.modernEncoding = modernEncoding
+		o.legacyEncoding = legacyEncoding
+	}
+}
+
+// WithEncoding sets the encoding of the release read by ReadRelease or
+// ReadFile.
+func WithEncoding(e Encoding) Option {
+	return func(o *Options) {
+		o.encoding = e
+	}
+}
+
 func WithDataDir(dir string) Option {
 	return func(o *Options) {
 		o.dataDir = dir
 	}
 }
 
+// WithCacheDir sets the directory where ReadRelease keeps the releases it
+// downloads.
+func WithCacheDir(dir string) Option {
+	return func(o *Options) {
+		o.cacheDir = dir
+	}
+}
+
 func WithHTTPClient(c *http.Client) Option {
 	return func(o *Options) {
 		o.client = c
 	}
 }
+
+// WithMaxSkippedRows allows up to n malformed rows to be skipped in each
+// release instead of failing on the first one.
+func WithMaxSkippedRows(n int) Option {
+	return func(o *Options) {
+		o.maxSkippedRows = n
+	}
+}
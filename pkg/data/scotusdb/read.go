@@ -5,10 +5,15 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"os"
+	"path"
 	"path/filepath"
 
 	"github.com/kellegous/scotus/pkg/csv"
 	"github.com/kellegous/scotus/pkg/data/internal"
+	"github.com/kellegous/scotus/pkg/logging"
+
+	"go.uber.org/zap"
 )
 
 const (
@@ -16,6 +21,10 @@ const (
 	modernCaseFilename = "SCDB_Modern_justiceCentered_Citation.csv.zip"
 )
 
+// CaseFunc is called for each case as it is completed while streaming
+// through the SCDB data. year is the term in which the case was decided.
+type CaseFunc func(year int, c *Case) error
+
 func Read(
 	ctx context.Context,
 	opts ...Option,
@@ -23,21 +32,129 @@ func Read(
 	var o Options
 	o.apply(opts)
 
-	legacySrc := filepath.Join(o.dataDir, legacyCaseFilename)
+	legacySrc, modernSrc, err := ensureSources(ctx, &o)
+	if err != nil {
+		return nil, err
+	}
+
+	legacy, err := readTermsFromCSV(ctx, legacySrc, o.csvOptions(o.legacyEncoding))
+	if err != nil {
+		return nil, err
+	}
+
+	modern, err := readTermsFromCSV(ctx, modernSrc, o.csvOptions(o.modernEncoding))
+	if err != nil {
+		return nil, err
+	}
+
+	terms, dups := mergeTerms(legacy, modern)
+
+	lg := logging.L(ctx).Named("data")
+	for _, id := range dups {
+		lg.Warn("case appears in more than one release, keeping the first",
+			zap.String("case", id))
+	}
+
+	return terms, nil
+}
+
+// ReadRelease reads the terms from a single justice-centered release at
+// url, which is downloaded into the cache directory if it isn't there
+// already. This is useful for comparing releases other than the defaults.
+func ReadRelease(
+	ctx context.Context,
+	url string,
+	opts ...Option,
+) ([]*Term, error) {
+	var o Options
+	o.apply(opts)
+
+	if err := os.MkdirAll(o.cacheDir, 0755); err != nil {
+		return nil, err
+	}
+
+	src := filepath.Join(o.cacheDir, path.Base(url))
 	if err := internal.EnsureDownload(
 		ctx,
 		o.client,
-		o.legacyCasesURL,
-		legacySrc,
+		url,
+		src,
 	); err != nil {
 		return nil, err
 	}
 
-	legacy, err := readTermsFromCSV(legacySrc)
+	terms, err := readTermsFromCSV(ctx, src, o.csvOptions(o.encoding))
+	if err != nil {
+		return nil, err
+	}
+
+	// there can be no duplicates within a single release.
+	terms, _ = mergeTerms(terms)
+	return terms, nil
+}
+
+// ReadFile reads the terms from a zipped justice-centered release on disk.
+func ReadFile(src string, opts ...Option) ([]*Term, error) {
+	var o Options
+	o.apply(opts)
+
+	terms, err := readTermsFromCSV(
+		context.Background(),
+		src,
+		o.csvOptions(o.encoding))
 	if err != nil {
 		return nil, err
 	}
 
+	// there can be no duplicates within a single release.
+	terms, _ = mergeTerms(terms)
+	return terms, nil
+}
+
+// ReadEach streams every case in the legacy and modern SCDB releases to
+// fn without retaining them. The justice-centered data has a row for each
+// vote, so a case is handed to fn once the rows for that case end. Unlike
+// Read, ReadEach relies on the rows of a case being contiguous, which is
+// the case for all published releases.
+func ReadEach(
+	ctx context.Context,
+	fn CaseFunc,
+	opts ...Option,
+) error {
+	var o Options
+	o.apply(opts)
+
+	legacySrc, modernSrc, err := ensureSources(ctx, &o)
+	if err != nil {
+		return err
+	}
+
+	if err := eachCaseInCSV(
+		ctx,
+		legacySrc,
+		fn,
+		o.csvOptions(o.legacyEncoding),
+	); err != nil {
+		return err
+	}
+
+	return eachCaseInCSV(ctx, modernSrc, fn, o.csvOptions(o.modernEncoding))
+}
+
+func ensureSources(
+	ctx context.Context,
+	o *Options,
+) (string, string, error) {
+	legacySrc := filepath.Join(o.dataDir, legacyCaseFilename)
+	if err := internal.EnsureDownload(
+		ctx,
+		o.client,
+		o.legacyCasesURL,
+		legacySrc,
+	); err != nil {
+		return "", "", err
+	}
+
 	modernSrc := filepath.Join(o.dataDir, modernCaseFilename)
 	if err := internal.EnsureDownload(
 		ctx,
@@ -45,78 +162,148 @@ func Read(
 		o.modernCasesURL,
 		modernSrc,
 	); err != nil {
-		return nil, err
+		return "", "", err
 	}
 
-	modern, err := readTermsFromCSV(modernSrc)
-	if err != nil {
+	return legacySrc, modernSrc, nil
+}
+
+func readTermsFromCSV(
+	ctx context.Context,
+	src string,
+	opts []csv.Option,
+) ([]*Term, error) {
+	termsByYear := map[int]*Term{}
+	casesByID := map[string]*Case{}
+	var terms []*Term
+
+	if err := eachCaseInCSV(
+		ctx,
+		src,
+		func(year int, c *Case) error {
+			t := termsByYear[year]
+			if t == nil {
+				t = &Term{Year: year}
+				termsByYear[year] = t
+				terms = append(terms, t)
+			}
+
+			// rows for a case that were not contiguous are folded into
+			// the case that was seen first.
+			if prev := casesByID[c.ID]; prev != nil {
+				prev.Votes = append(prev.Votes, c.Votes...)
+				return nil
+			}
+
+			casesByID[c.ID] = c
+			t.Cases = append(t.Cases, c)
+			return nil
+		},
+		opts,
+	); err != nil {
 		return nil, err
 	}
 
-	return append(legacy, modern...), nil
+	return terms, nil
 }
 
-func readTermsFromCSV(src string) ([]*Term, error) {
+func eachCaseInCSV(
+	ctx context.Context,
+	src string,
+	fn CaseFunc,
+	opts []csv.Option,
+) error {
 	zr, err := zip.OpenReader(src)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	defer zr.Close()
 
 	if n := len(zr.File); n != 1 {
-		return nil, fmt.Errorf(
+		return fmt.Errorf(
 			"expected a single file but there are %d",
 			n)
 	}
 
 	r, err := zr.File[0].Open()
 	if err != nil {
-		return nil, err
+		return err
 	}
 	defer r.Close()
 
-	cr, err := csv.NewReader(r)
+	cr, err := csv.NewReader(r, opts...)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	termsByYear := map[int]*Term{}
-	casesByID := map[string]*Case{}
-	var terms []*Term
+	var c *Case
+	var year int
 
 	for {
 		row, err := cr.Next()
 		if err == io.EOF {
 			break
 		} else if err != nil {
-			return nil, err
+			return err
 		}
 
-		t, added, err := readTerm(termsByYear, row)
+		id, err := row.Get("caseId")
 		if err != nil {
-			return nil, err
+			if err := cr.Skip(err); err != nil {
+				return err
+			}
+			continue
 		}
 
-		if added {
-			terms = append(terms, t)
-		}
+		if c == nil || c.ID != id {
+			if c != nil {
+				if err := fn(year, c); err != nil {
+					return err
+				}
+				c = nil
+			}
 
-		c, added, err := readCase(casesByID, row)
-		if err != nil {
-			return nil, err
-		}
+			if err := ctx.Err(); err != nil {
+				return err
+			}
 
-		if added {
-			t.Cases = append(t.Cases, c)
+			y, err := readTermYear(row)
+			if err != nil {
+				if err := cr.Skip(err); err != nil {
+					return err
+				}
+				continue
+			}
+
+			nc, err := readCase(id, row)
+			if err != nil {
+				if err := cr.Skip(err); err != nil {
+					return err
+				}
+				continue
+			}
+
+			year, c = y, nc
 		}
 
 		v, err := readVote(row)
 		if err != nil {
-			return nil, err
+			if err := cr.Skip(err); err != nil {
+				return err
+			}
+			continue
 		}
 
 		c.Votes = append(c.Votes, v)
 	}
 
-	return terms, nil
+	if c != nil {
+		if err := fn(year, c); err != nil {
+			return err
+		}
+	}
+
+	internal.LogSkipped(ctx, src, cr)
+
+	return nil
 }
@@ -13,26 +13,15 @@ type Term struct {
 	Cases []*Case `json:"cases"`
 }
 
-func readTerm(
-	terms map[int]*Term,
-	row *csv.Row,
-) (*Term, bool, error) {
+func readTermYear(row *csv.Row) (int, error) {
 	year, err := row.GetInt("term", strconv.Atoi)
 	if err != nil {
-		return nil, false, err
+		return 0, err
 	}
 
 	if year < 1700 || year > time.Now().Year() {
-		return nil, false, fmt.Errorf("invalid term year: %d", year)
+		return 0, fmt.Errorf("invalid term year: %d", year)
 	}
 
-	if t := terms[year]; t != nil {
-		return t, false, nil
-	}
-
-	t := &Term{
-		Year: year,
-	}
-	terms[year] = t
-	return t, true, nil
+	return year, nil
 }
@@ -15,60 +15,49 @@ const (
 	Conservative Direction = "C"
 )
 
+func (d Direction) MarshalText() ([]byte, error) {
+	return []byte(d), nil
+}
+
+// UnmarshalText accepts both the SCDB coding of a direction and the
+// symbols used by Direction itself.
+func (d *Direction) UnmarshalText(b []byte) error {
+	dir, err := directionFromString(string(b))
+	if err != nil {
+		return err
+	}
+	*d = dir
+	return nil
+}
+
 type Vote struct {
-	ID          string    `json:"id"`
-	JusticeName string    `json:"justice-name"`
-	Decision    Decision  `json:"decision"`
-	Direction   Direction `json:"direction"`
+	ID          string    `json:"id" csv:"voteId"`
+	JusticeName string    `json:"justice-name" csv:"justiceName"`
+	Decision    Decision  `json:"decision" csv:"majority"`
+	Direction   Direction `json:"direction" csv:"direction"`
+
+	// Kind is the SCDB code for the way the justice voted, e.g. 1 for
+	// with the majority, 2 for a dissent or 3 for a concurrence. It is
+	// empty when it isn't known.
+	Kind string `json:"kind" csv:"vote"`
 }
 
 func directionFromString(s string) (Direction, error) {
 	switch strings.TrimSpace(s) {
-	case "1":
+	case "1", string(Conservative):
 		return Conservative, nil
-	case "2":
+	case "2", string(Liberal):
 		return Liberal, nil
-	case "":
+	case "", string(Unknown):
 		return Unknown, nil
 	}
 	return Unknown, fmt.Errorf("invalid direction: %s", s)
 }
 
 func readVote(row *csv.Row) (*Vote, error) {
-	id, err := row.Get("voteId")
-	if err != nil {
-		return nil, err
-	}
-
-	justiceName, err := row.Get("justiceName")
-	if err != nil {
-		return nil, err
-	}
-
-	majority, err := row.Get("majority")
-	if err != nil {
-		return nil, err
-	}
-
-	des, err := decisionFromString(majority)
-	if err != nil {
-		return nil, err
-	}
-
-	dir, err := row.Get("direction")
-	if err != nil {
+	var v Vote
+	if err := row.Decode(&v); err != nil {
 		return nil, err
 	}
-
-	direction, err := directionFromString(dir)
-	if err != nil {
-		return nil, err
-	}
-
-	return &Vote{
-		ID:          id,
-		JusticeName: justiceName,
-		Decision:    des,
-		Direction:   direction,
-	}, nil
+	return &v, nil
 }
@@ -1,8 +1,8 @@
 package segalcover
 
 import (
-	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -21,6 +21,8 @@ const (
 	DefaultURL   = `https://en.wikipedia.org/wiki/Segal%E2%80%93Cover_score`
 )
 
+var tableSelector = search.MustCompile("table#segalcover")
+
 func Read(
 	ctx context.Context,
 	opts ...option.DownloadOption,
@@ -48,35 +50,18 @@ func Read(
 	return read(r)
 }
 
-func getInnerText(n *html.Node) string {
-	var buf bytes.Buffer
-	collectInnerText(n, &buf)
-	return buf.String()
-}
-
-func collectInnerText(n *html.Node, buf *bytes.Buffer) {
-	if n.Type == html.TextNode {
-		buf.WriteString(n.Data)
-	}
-	for c := n.FirstChild; c != nil; c = c.NextSibling {
-		collectInnerText(c, buf)
-	}
-}
-
-func parseNominator(n *html.Node) (*President, error) {
-	a := n.FirstChild
-	if a == nil || a.Type != html.ElementNode || a.Data != "a" {
-		return nil, fmt.Errorf("first child is not a element")
+func parseNominator(c *search.Cell) (*President, error) {
+	if len(c.Links) == 0 {
+		return nil, fmt.Errorf("no link to the president")
 	}
-	name := strings.TrimSpace(getInnerText(a))
+	name := c.Links[0].Text
 
-	b := a.NextSibling
-	if b == nil || b.Type != html.TextNode {
-		return nil, fmt.Errorf("second child is not text")
+	if !strings.HasPrefix(c.Text, name) {
+		return nil, fmt.Errorf("party does not follow the president's name")
 	}
 
 	party, err := partyFromString(
-		strings.TrimSpace(getInnerText(b)))
+		strings.TrimSpace(strings.TrimPrefix(c.Text, name)))
 	if err != nil {
 		return nil, err
 	}
@@ -87,32 +72,59 @@ func parseNominator(n *html.Node) (*President, error) {
 	}, nil
 }
 
-func parseJustice(tds []*html.Node) (*Justice, error) {
-	if n := len(tds); n != 8 {
-		return nil, fmt.Errorf("expected 8 columns, but found %d", n)
+// columns holds the headers of the columns used to build a Justice.
+type columns struct {
+	name      string
+	position  string
+	ideology  string
+	nominator string
+	year      string
+}
+
+// findColumns finds the columns of the Segal-Cover table. Each column is
+// matched by any of a few names since the headers have been worded
+// differently over the years.
+func findColumns(t *search.Table) (*columns, error) {
+	var cols columns
+	for _, c := range []struct {
+		dst     *string
+		matches []string
+	}{
+		{&cols.ideology, []string{"ideology"}},
+		{&cols.nominator, []string{"nominat", "president"}},
+		{&cols.name, []string{"nominee", "justice", "name"}},
+		{&cols.position, []string{"position", "seat"}},
+		{&cols.year, []string{"year"}},
+	} {
+		h, err := t.FindHeader(c.matches...)
+		if err != nil {
+			return nil, err
+		}
+		*c.dst = h
 	}
+	return &cols, nil
+}
 
-	asChief := strings.TrimSpace(getInnerText(tds[2])) == "CJ"
+func parseJustice(cols *columns, rec search.Record) (*Justice, error) {
+	asChief := rec.Get(cols.position).Text == "CJ"
 
-	is := strings.TrimSpace(getInnerText(tds[4]))
-	ideology, err := strconv.ParseFloat(is, 64)
+	ideology, err := strconv.ParseFloat(rec.Get(cols.ideology).Text, 64)
 	if err != nil {
 		return nil, fmt.Errorf("ideology score: %w", err)
 	}
 
-	nominator, err := parseNominator(tds[6])
+	nominator, err := parseNominator(rec.Get(cols.nominator))
 	if err != nil {
 		return nil, fmt.Errorf("nominator: %w", err)
 	}
 
-	ys := strings.TrimSpace(getInnerText(tds[7]))
-	year, err := strconv.Atoi(ys)
+	year, err := strconv.Atoi(rec.Get(cols.year).Text)
 	if err != nil {
 		return nil, fmt.Errorf("year: %w", err)
 	}
 
 	return &Justice{
-		Name:          strings.TrimSpace(getInnerText(tds[1])),
+		Name:          rec.Get(cols.name).Text,
 		Chief:         asChief,
 		Ideology:      ideology,
 		YearNominated: year,
@@ -126,27 +138,24 @@ func read(r io.Reader) ([]*Justice, error) {
 		return nil, err
 	}
 
-	trs := search.Query(
-		doc,
-		search.HasAll(
-			search.HasAll(
-				search.IsElementOf("tr"),
-				search.HasParent(
-					search.HasAll(
-						search.IsElementOf("tbody"),
-						search.HasParent(
-							search.HasID("segalcover"),
-						),
-					),
-				),
-			),
-		),
-	)
-
-	var justices []*Justice
-	for _, tr := range trs[1:] {
-		justice, err := parseJustice(
-			search.Query(tr, search.IsElementOf("td")))
+	n := search.QueryFirst(doc, tableSelector)
+	if n == nil {
+		return nil, errors.New("table#segalcover not found")
+	}
+
+	t, err := search.ReadTable(n)
+	if err != nil {
+		return nil, err
+	}
+
+	cols, err := findColumns(t)
+	if err != nil {
+		return nil, err
+	}
+
+	justices := make([]*Justice, 0, len(t.Rows))
+	for _, rec := range t.Rows {
+		justice, err := parseJustice(cols, rec)
 		if err != nil {
 			return nil, err
 		}
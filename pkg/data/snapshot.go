@@ -0,0 +1,231 @@
+package data
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/gob"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/kellegous/scotus/pkg/build"
+)
+
+const (
+	snapshotFilename = "model.snapshot"
+
+	// snapshotVersion must be bumped whenever the shape of Model, or any
+	// of the types it contains, changes. Changes to how values are parsed
+	// are caught by the build id.
+	snapshotVersion = 9
+)
+
+var (
+	snapshotMagic = [8]byte{'s', 'c', 'o', 't', 'u', 's', 'm', 'd'}
+
+	errStaleSnapshot = errors.New("snapshot is stale")
+)
+
+type snapshotHeader struct {
+	Magic   [8]byte
+	Version int
+	Build   string
+	Sources map[string]string
+}
+
+// buildID identifies the code that built a model, so that a snapshot is
+// not reused after the parsers change. That is the VCS revision for clean
+// builds. Otherwise, it is the hash of the executable itself. An empty id
+// means the build can't be identified and no snapshot will be trusted.
+func buildID() string {
+	if b, err := build.Read(); err == nil && !b.Modified && b.Version != build.DevVersion {
+		return b.Version
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		return ""
+	}
+
+	h, err := hashFile(exe)
+	if err != nil {
+		return ""
+	}
+
+	return "exe:" + h
+}
+
+func snapshotPath(dataDir string) string {
+	return filepath.Join(dataDir, snapshotFilename)
+}
+
+// hashSources computes a sha256 for every regular file in the data
+// directory other than the snapshot itself.
+func hashSources(dataDir string) (map[string]string, error) {
+	entries, err := os.ReadDir(dataDir)
+	if err != nil {
+		return nil, err
+	}
+
+	sources := map[string]string{}
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() || entry.Name() == snapshotFilename {
+			continue
+		}
+
+		h, err := hashFile(filepath.Join(dataDir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		sources[entry.Name()] = h
+	}
+
+	return sources, nil
+}
+
+func hashFile(src string) (string, error) {
+	r, err := os.Open(src)
+	if err != nil {
+		return "", err
+	}
+	defer r.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, r); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// changedSources returns the names of the sources that were added,
+// removed or modified between a and b.
+func changedSources(a, b map[string]string) []string {
+	var changed []string
+	for name, h := range a {
+		if b[name] != h {
+			changed = append(changed, name)
+		}
+	}
+
+	for name := range b {
+		if _, ok := a[name]; !ok {
+			changed = append(changed, name)
+		}
+	}
+
+	sort.Strings(changed)
+	return changed
+}
+
+// ReadSnapshot reads the model from the snapshot in dataDir. It returns
+// an error if there is no snapshot, if it was written by a different
+// version or build or if the source files have changed since it was
+// written.
+func ReadSnapshot(dataDir string) (*Model, error) {
+	r, err := os.Open(snapshotPath(dataDir))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	dec := gob.NewDecoder(bufio.NewReader(r))
+
+	var hdr snapshotHeader
+	if err := dec.Decode(&hdr); err != nil {
+		return nil, fmt.Errorf("snapshot header: %w", err)
+	}
+
+	if hdr.Magic != snapshotMagic {
+		return nil, errors.New("not a model snapshot")
+	}
+
+	if hdr.Version != snapshotVersion {
+		return nil, fmt.Errorf(
+			"%w: version %d, expected %d",
+			errStaleSnapshot,
+			hdr.Version,
+			snapshotVersion)
+	}
+
+	if id := buildID(); id == "" || hdr.Build != id {
+		return nil, fmt.Errorf(
+			"%w: built by %q, running %q",
+			errStaleSnapshot,
+			hdr.Build,
+			id)
+	}
+
+	sources, err := hashSources(dataDir)
+	if err != nil {
+		return nil, err
+	}
+
+	if changed := changedSources(hdr.Sources, sources); len(changed) > 0 {
+		return nil, fmt.Errorf(
+			"%w: sources changed %v",
+			errStaleSnapshot,
+			changed)
+	}
+
+	var m Model
+	if err := dec.Decode(&m); err != nil {
+		return nil, fmt.Errorf("snapshot model: %w", err)
+	}
+
+	return &m, nil
+}
+
+// WriteSnapshot writes the model to a snapshot in dataDir along with the
+// hashes of the source files it was built from.
+func WriteSnapshot(dataDir string, m *Model) error {
+	sources, err := hashSources(dataDir)
+	if err != nil {
+		return err
+	}
+
+	dst := snapshotPath(dataDir)
+	tmp := dst + ".tmp"
+
+	w, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp)
+	defer w.Close()
+
+	bw := bufio.NewWriter(w)
+	enc := gob.NewEncoder(bw)
+
+	if err := enc.Encode(&snapshotHeader{
+		Magic:   snapshotMagic,
+		Version: snapshotVersion,
+		Build:   buildID(),
+		Sources: sources,
+	}); err != nil {
+		return err
+	}
+
+	if err := enc.Encode(m); err != nil {
+		return err
+	}
+
+	if err := bw.Flush(); err != nil {
+		return err
+	}
+
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, dst)
+}
+
+func isStaleSnapshot(err error) bool {
+	return errors.Is(err, errStaleSnapshot) || errors.Is(err, os.ErrNotExist)
+}
@@ -0,0 +1,62 @@
+package data
+
+import (
+	"os"
+	"time"
+)
+
+// Sizes returns the number of rows in each of the Tables.
+func (m *Model) Sizes() map[string]int {
+	var cases, votes, courts, justices, overruled int
+	for _, t := range m.SCOTUSDBCases {
+		cases += len(t.Cases)
+		for _, c := range t.Cases {
+			votes += len(c.Votes)
+		}
+	}
+
+	for _, c := range m.MartinQuinnByYear {
+		courts += len(c.Stats)
+	}
+
+	for _, t := range m.MartinQuinnByJustice {
+		justices += len(t.Justices)
+	}
+
+	for _, d := range m.Overrulings {
+		overruled += len(d.Overruled)
+	}
+
+	return map[string]int{
+		"cases":                cases,
+		"votes":                votes,
+		"martinquinn-courts":   courts,
+		"martinquinn-justices": justices,
+		"overrulings":          overruled,
+	}
+}
+
+// SourceTimes returns the modification times of the source files in
+// dataDir, which is when each of them was downloaded.
+func SourceTimes(dataDir string) (map[string]time.Time, error) {
+	entries, err := os.ReadDir(dataDir)
+	if err != nil {
+		return nil, err
+	}
+
+	times := map[string]time.Time{}
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() || entry.Name() == snapshotFilename {
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return nil, err
+		}
+
+		times[entry.Name()] = info.ModTime()
+	}
+
+	return times, nil
+}
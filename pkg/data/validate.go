@@ -0,0 +1,220 @@
+package data
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/kellegous/scotus/pkg/data/martinquinn/byjustice"
+	"github.com/kellegous/scotus/pkg/data/scotusdb"
+)
+
+// maxVotes is the size of the modern court. The court had ten seats
+// briefly in the 1860s, so cases from those terms will be reported.
+const maxVotes = 9
+
+type AnomalyKind string
+
+const (
+	MajorityVotesMismatch AnomalyKind = "majority-votes-mismatch"
+	MinorityVotesMismatch AnomalyKind = "minority-votes-mismatch"
+	ChiefDidNotSit        AnomalyKind = "chief-did-not-sit"
+	TooManyVotes          AnomalyKind = "too-many-votes"
+	MedianJusticeMismatch AnomalyKind = "median-justice-mismatch"
+	MissingJusticeTerm    AnomalyKind = "missing-justice-term"
+	OverruledAfterRuling  AnomalyKind = "overruled-after-ruling"
+)
+
+type Anomaly struct {
+	Kind    AnomalyKind `json:"kind"`
+	Subject string      `json:"subject"`
+	Message string      `json:"message"`
+}
+
+type Report struct {
+	Counts    map[AnomalyKind]int `json:"counts"`
+	Anomalies []*Anomaly          `json:"anomalies"`
+}
+
+func (r *Report) add(
+	kind AnomalyKind,
+	subject string,
+	format string,
+	args ...interface{},
+) {
+	r.Counts[kind]++
+	r.Anomalies = append(r.Anomalies, &Anomaly{
+		Kind:    kind,
+		Subject: subject,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// Total returns the number of anomalies in the report.
+func (r *Report) Total() int {
+	return len(r.Anomalies)
+}
+
+// Validate checks that the datasets in the model agree with themselves and
+// with each other. Nothing in the report is fatal; these are things that
+// deserve a closer look before the numbers are published.
+func Validate(m *Model) *Report {
+	r := &Report{
+		Counts: map[AnomalyKind]int{},
+	}
+
+	for _, t := range m.SCOTUSDBCases {
+		for _, c := range t.Cases {
+			validateCase(r, c)
+		}
+	}
+
+	validateMedians(r, m)
+	validateOverrulings(r, m)
+
+	return r
+}
+
+func validateCase(r *Report, c *scotusdb.Case) {
+	var with, against int
+	chiefSat := c.Chief == ""
+	for _, v := range c.Votes {
+		switch v.Decision {
+		case scotusdb.WithMajority:
+			with++
+		case scotusdb.AgainstMajority:
+			against++
+		}
+
+		// SCDB names chiefs by surname only (e.g. Roberts) and justices
+		// with their initials (e.g. JGRoberts).
+		if v.Decision != scotusdb.Abstained && strings.HasSuffix(v.JusticeName, c.Chief) {
+			chiefSat = true
+		}
+	}
+
+	// unknown counts are recorded as -1.
+	if c.MajorityVotes >= 0 && c.MajorityVotes != with {
+		r.add(
+			MajorityVotesMismatch,
+			c.ID,
+			"majority votes is %d, but %d justices voted with the majority",
+			c.MajorityVotes,
+			with)
+	}
+
+	if c.MinorityVotes >= 0 && c.MinorityVotes != against {
+		r.add(
+			MinorityVotesMismatch,
+			c.ID,
+			"minority votes is %d, but %d justices voted against the majority",
+			c.MinorityVotes,
+			against)
+	}
+
+	if !chiefSat {
+		r.add(
+			ChiefDidNotSit,
+			c.ID,
+			"chief %s did not vote",
+			c.Chief)
+	}
+
+	if n := len(c.Votes); n > maxVotes {
+		r.add(
+			TooManyVotes,
+			c.ID,
+			"%d justices recorded",
+			n)
+	}
+
+	if n := c.MajorityVotes + c.MinorityVotes; n > maxVotes {
+		r.add(
+			TooManyVotes,
+			c.ID,
+			"%d majority and minority votes",
+			n)
+	}
+}
+
+// medianJusticeOf finds the justice with the median score in the term. If
+// the term has an even number of justices, there is no single median and
+// nil is returned.
+func medianJusticeOf(t *byjustice.Term) *byjustice.Justice {
+	if len(t.Justices)%2 == 0 {
+		return nil
+	}
+
+	justices := make([]*byjustice.Justice, len(t.Justices))
+	copy(justices, t.Justices)
+	sort.Slice(justices, func(i, j int) bool {
+		return justices[i].Median < justices[j].Median
+	})
+
+	return justices[len(justices)/2]
+}
+
+func validateMedians(r *Report, m *Model) {
+	termsByYear := map[int]*byjustice.Term{}
+	for _, t := range m.MartinQuinnByJustice {
+		termsByYear[t.Year] = t
+	}
+
+	for _, court := range m.MartinQuinnByYear {
+		subject := fmt.Sprintf("%d", court.Year)
+
+		t := termsByYear[court.Year]
+		if t == nil {
+			r.add(
+				MissingJusticeTerm,
+				subject,
+				"no justice scores for term")
+			continue
+		}
+
+		expected := medianJusticeOf(t)
+		if expected == nil {
+			continue
+		}
+
+		// the court scores identify the median justice by the same
+		// numeric id as the justice column of the justice scores.
+		id := strconv.Itoa(expected.ID)
+
+		var found []string
+		matched := false
+		for _, s := range court.Stats {
+			if s.MedianJustice == id {
+				matched = true
+			}
+			found = append(found, s.MedianJustice)
+		}
+
+		if !matched {
+			r.add(
+				MedianJusticeMismatch,
+				subject,
+				"court median is justice %s, but the median of the justice scores is %s (%s)",
+				strings.Join(found, ", "),
+				id,
+				expected.Name)
+		}
+	}
+}
+
+func validateOverrulings(r *Report, m *Model) {
+	for _, d := range m.Overrulings {
+		for _, c := range d.Overruled {
+			if c.Year > d.Year {
+				r.add(
+					OverruledAfterRuling,
+					d.Name,
+					"overrules %s from %d, which came after %d",
+					c.Name,
+					c.Year,
+					d.Year)
+			}
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package data
+
+import (
+	"testing"
+
+	"github.com/kellegous/scotus/pkg/data/martinquinn/bycourt"
+	"github.com/kellegous/scotus/pkg/data/martinquinn/byjustice"
+)
+
+// term2019 is the 2019 term as it appears in justices.csv, where justice is
+// the numeric id and justiceName is the SCDB name.
+func term2019() *byjustice.Term {
+	return &byjustice.Term{
+		Year: 2019,
+		Justices: []*byjustice.Justice{
+			{ID: 106, Name: "CThomas", Median: 3.186},
+			{ID: 107, Name: "RBGinsburg", Median: -2.245},
+			{ID: 108, Name: "SGBreyer", Median: -1.473},
+			{ID: 111, Name: "JGRoberts", Median: 0.265},
+			{ID: 112, Name: "SAAlito", Median: 2.004},
+			{ID: 113, Name: "SSotomayor", Median: -2.987},
+			{ID: 114, Name: "EKagan", Median: -1.591},
+			{ID: 115, Name: "NMGorsuch", Median: 1.369},
+			{ID: 116, Name: "BMKavanaugh", Median: 0.512},
+		},
+	}
+}
+
+func TestValidateMedians(t *testing.T) {
+	tests := []struct {
+		name     string
+		court    *bycourt.Court
+		terms    []*byjustice.Term
+		expected map[AnomalyKind]int
+	}{
+		{
+			name: "matching median",
+			court: &bycourt.Court{
+				Year: 2019,
+				Stats: []*bycourt.Stats{
+					{MedianJusticeScore: 0.265, MedianJustice: "111"},
+				},
+			},
+			terms:    []*byjustice.Term{term2019()},
+			expected: map[AnomalyKind]int{},
+		},
+		{
+			name: "matching one of several medians",
+			court: &bycourt.Court{
+				Year: 2019,
+				Stats: []*bycourt.Stats{
+					{MedianJusticeScore: 0.512, MedianJustice: "116"},
+					{MedianJusticeScore: 0.265, MedianJustice: "111"},
+				},
+			},
+			terms:    []*byjustice.Term{term2019()},
+			expected: map[AnomalyKind]int{},
+		},
+		{
+			name: "different median",
+			court: &bycourt.Court{
+				Year: 2019,
+				Stats: []*bycourt.Stats{
+					{MedianJusticeScore: 0.512, MedianJustice: "116"},
+				},
+			},
+			terms: []*byjustice.Term{term2019()},
+			expected: map[AnomalyKind]int{
+				MedianJusticeMismatch: 1,
+			},
+		},
+		{
+			name: "missing term",
+			court: &bycourt.Court{
+				Year: 2020,
+				Stats: []*bycourt.Stats{
+					{MedianJusticeScore: 0.512, MedianJustice: "116"},
+				},
+			},
+			terms: []*byjustice.Term{term2019()},
+			expected: map[AnomalyKind]int{
+				MissingJusticeTerm: 1,
+			},
+		},
+		{
+			name: "even number of justices",
+			court: &bycourt.Court{
+				Year: 2019,
+				Stats: []*bycourt.Stats{
+					{MedianJusticeScore: 0.512, MedianJustice: "116"},
+				},
+			},
+			terms: func() []*byjustice.Term {
+				t := term2019()
+				t.Justices = t.Justices[1:]
+				return []*byjustice.Term{t}
+			}(),
+			expected: map[AnomalyKind]int{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := &Report{Counts: map[AnomalyKind]int{}}
+			validateMedians(r, &Model{
+				MartinQuinnByYear:    []*bycourt.Court{test.court},
+				MartinQuinnByJustice: test.terms,
+			})
+
+			if len(r.Counts) != len(test.expected) {
+				t.Fatalf("expected %v, got %v", test.expected, r.Counts)
+			}
+			for kind, n := range test.expected {
+				if r.Counts[kind] != n {
+					t.Fatalf("expected %v, got %v", test.expected, r.Counts)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,427 @@
+package search
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// Compile parses a CSS selector into a Selector. It supports type (tr),
+// universal (*), id (#segalcover), class (.wikitable) and attribute
+// ([href], [rel=nofollow], and the ~=, |=, ^=, $= and *= operators)
+// selectors, the descendant, child (>), adjacent sibling (+) and general
+// sibling (~) combinators, selector lists (a, b) and the :first-child,
+// :last-child, :only-child, :nth-child(an+b) and :not(...) pseudo-classes.
+func Compile(s string) (Selector, error) {
+	p := parser{src: s}
+	sel, err := p.parseList()
+	if err != nil {
+		return nil, err
+	}
+
+	p.skipSpace()
+	if !p.done() {
+		return nil, p.errorf("unexpected %q", p.peek())
+	}
+
+	return sel, nil
+}
+
+// MustCompile is like Compile but panics if the selector is invalid. It is
+// intended for selectors that are known at compile time.
+func MustCompile(s string) Selector {
+	sel, err := Compile(s)
+	if err != nil {
+		panic(err)
+	}
+	return sel
+}
+
+type parser struct {
+	src string
+	pos int
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf(
+		"invalid selector %q at %d: %s",
+		p.src,
+		p.pos,
+		fmt.Sprintf(format, args...))
+}
+
+func (p *parser) done() bool {
+	return p.pos >= len(p.src)
+}
+
+func (p *parser) peek() byte {
+	if p.done() {
+		return 0
+	}
+	return p.src[p.pos]
+}
+
+func (p *parser) skipSpace() bool {
+	start := p.pos
+	for !p.done() && isSpace(p.src[p.pos]) {
+		p.pos++
+	}
+	return p.pos > start
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f'
+}
+
+func isNameChar(c byte) bool {
+	return c == '-' || c == '_' ||
+		(c >= 'a' && c <= 'z') ||
+		(c >= 'A' && c <= 'Z') ||
+		(c >= '0' && c <= '9') ||
+		c >= 0x80
+}
+
+func (p *parser) parseName() (string, error) {
+	start := p.pos
+	for !p.done() && isNameChar(p.src[p.pos]) {
+		p.pos++
+	}
+
+	if p.pos == start {
+		return "", p.errorf("expected a name")
+	}
+
+	return p.src[start:p.pos], nil
+}
+
+// parseList parses a comma separated list of complex selectors.
+func (p *parser) parseList() (Selector, error) {
+	var sels []Selector
+	for {
+		p.skipSpace()
+		sel, err := p.parseComplex()
+		if err != nil {
+			return nil, err
+		}
+		sels = append(sels, sel)
+
+		p.skipSpace()
+		if p.peek() != ',' {
+			break
+		}
+		p.pos++
+	}
+
+	return HasAny(sels[0], sels[1:]...), nil
+}
+
+// parseComplex parses compound selectors joined by combinators. Since
+// matching starts with the node itself, each combinator relates the
+// compound to its right with everything to its left.
+func (p *parser) parseComplex() (Selector, error) {
+	sel, err := p.parseCompound()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		hadSpace := p.skipSpace()
+
+		var relation func(Selector) Selector
+		switch c := p.peek(); c {
+		case '>':
+			relation = HasDirectParent
+		case '+':
+			relation = HasDirectPrevSibling
+		case '~':
+			relation = HasPrevSibling
+		case ',', ')', 0:
+			return sel, nil
+		default:
+			if !hadSpace {
+				return nil, p.errorf("unexpected %q", c)
+			}
+			relation = HasParent
+		}
+
+		// the descendant combinator is just whitespace, which has already
+		// been skipped.
+		if c := p.peek(); c == '>' || c == '+' || c == '~' {
+			p.pos++
+			p.skipSpace()
+		}
+
+		next, err := p.parseCompound()
+		if err != nil {
+			return nil, err
+		}
+
+		sel = HasAll(next, relation(sel))
+	}
+}
+
+// parseCompound parses a type selector followed by any number of id,
+// class, attribute and pseudo-class selectors.
+func (p *parser) parseCompound() (Selector, error) {
+	var sels []Selector
+
+	switch c := p.peek(); {
+	case c == '*':
+		p.pos++
+		sels = append(sels, isElement)
+	case isNameChar(c):
+		name, err := p.parseName()
+		if err != nil {
+			return nil, err
+		}
+		sels = append(sels, IsElementOf(strings.ToLower(name)))
+	}
+
+	for {
+		var sel Selector
+		var err error
+
+		switch p.peek() {
+		case '#':
+			p.pos++
+			var id string
+			id, err = p.parseName()
+			sel = HasID(id)
+		case '.':
+			p.pos++
+			var class string
+			class, err = p.parseName()
+			sel = HasClass(class)
+		case '[':
+			p.pos++
+			sel, err = p.parseAttr()
+		case ':':
+			p.pos++
+			sel, err = p.parsePseudo()
+		default:
+			if len(sels) == 0 {
+				return nil, p.errorf("expected a selector")
+			}
+			return HasAll(isElement, sels...), nil
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		sels = append(sels, sel)
+	}
+}
+
+func (p *parser) parseString() (string, error) {
+	q := p.peek()
+	if q != '"' && q != '\'' {
+		return p.parseName()
+	}
+
+	p.pos++
+	start := p.pos
+	for !p.done() && p.src[p.pos] != q {
+		p.pos++
+	}
+
+	if p.done() {
+		return "", p.errorf("unterminated string")
+	}
+
+	s := p.src[start:p.pos]
+	p.pos++
+	return s, nil
+}
+
+func (p *parser) parseAttr() (Selector, error) {
+	p.skipSpace()
+	name, err := p.parseName()
+	if err != nil {
+		return nil, err
+	}
+	name = strings.ToLower(name)
+	p.skipSpace()
+
+	if p.peek() == ']' {
+		p.pos++
+		return HasAttr(name), nil
+	}
+
+	var op byte
+	if c := p.peek(); c == '~' || c == '|' || c == '^' || c == '$' || c == '*' {
+		op = c
+		p.pos++
+	}
+
+	if p.peek() != '=' {
+		return nil, p.errorf("expected =")
+	}
+	p.pos++
+	p.skipSpace()
+
+	val, err := p.parseString()
+	if err != nil {
+		return nil, err
+	}
+
+	p.skipSpace()
+	if p.peek() != ']' {
+		return nil, p.errorf("expected ]")
+	}
+	p.pos++
+
+	var match func(v string) bool
+	switch op {
+	case 0:
+		match = func(v string) bool { return v == val }
+	case '~':
+		match = func(v string) bool {
+			for _, w := range whitespacePattern.Split(v, -1) {
+				if w == val {
+					return true
+				}
+			}
+			return false
+		}
+	case '|':
+		match = func(v string) bool { return v == val || strings.HasPrefix(v, val+"-") }
+	case '^':
+		match = func(v string) bool { return val != "" && strings.HasPrefix(v, val) }
+	case '$':
+		match = func(v string) bool { return val != "" && strings.HasSuffix(v, val) }
+	case '*':
+		match = func(v string) bool { return val != "" && strings.Contains(v, val) }
+	}
+
+	return HasAttrMatching(name, match), nil
+}
+
+func (p *parser) parseArgs() (string, error) {
+	if p.peek() != '(' {
+		return "", p.errorf("expected (")
+	}
+	p.pos++
+
+	start := p.pos
+	for !p.done() && p.src[p.pos] != ')' {
+		p.pos++
+	}
+
+	if p.done() {
+		return "", p.errorf("expected )")
+	}
+
+	args := p.src[start:p.pos]
+	p.pos++
+	return strings.TrimSpace(args), nil
+}
+
+func (p *parser) parsePseudo() (Selector, error) {
+	name, err := p.parseName()
+	if err != nil {
+		return nil, err
+	}
+
+	switch strings.ToLower(name) {
+	case "first-child":
+		return IsNthChild(0, 1), nil
+	case "last-child":
+		return isLastChild, nil
+	case "only-child":
+		return HasAll(IsNthChild(0, 1), isLastChild), nil
+	case "nth-child":
+		args, err := p.parseArgs()
+		if err != nil {
+			return nil, err
+		}
+
+		a, b, err := parseNth(args)
+		if err != nil {
+			return nil, p.errorf("%s", err)
+		}
+
+		return IsNthChild(a, b), nil
+	case "not":
+		if p.peek() != '(' {
+			return nil, p.errorf("expected (")
+		}
+		p.pos++
+
+		sel, err := p.parseList()
+		if err != nil {
+			return nil, err
+		}
+
+		p.skipSpace()
+		if p.peek() != ')' {
+			return nil, p.errorf("expected )")
+		}
+		p.pos++
+
+		return Not(sel), nil
+	}
+
+	return nil, p.errorf("unsupported pseudo-class :%s", name)
+}
+
+// parseNth parses the an+b argument to :nth-child, including the odd and
+// even keywords.
+func parseNth(s string) (int, int, error) {
+	s = strings.ToLower(strings.ReplaceAll(s, " ", ""))
+	switch s {
+	case "odd":
+		return 2, 1, nil
+	case "even":
+		return 2, 0, nil
+	}
+
+	i := strings.IndexByte(s, 'n')
+	if i < 0 {
+		b, err := strconv.Atoi(s)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid nth-child: %s", s)
+		}
+		return 0, b, nil
+	}
+
+	var a int
+	switch as := s[:i]; as {
+	case "", "+":
+		a = 1
+	case "-":
+		a = -1
+	default:
+		var err error
+		a, err = strconv.Atoi(as)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid nth-child: %s", s)
+		}
+	}
+
+	var b int
+	if bs := s[i+1:]; bs != "" {
+		var err error
+		b, err = strconv.Atoi(bs)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid nth-child: %s", s)
+		}
+	}
+
+	return a, b, nil
+}
+
+func isElement(n *html.Node) bool {
+	return n.Type == html.ElementNode
+}
+
+func isLastChild(n *html.Node) bool {
+	for s := n.NextSibling; s != nil; s = s.NextSibling {
+		if s.Type == html.ElementNode {
+			return false
+		}
+	}
+	return n.Parent != nil
+}
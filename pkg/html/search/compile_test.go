@@ -0,0 +1,149 @@
+package search
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+)
+
+const compileDoc = `<div id="root">
+	<ul id="list">
+		<li id="l1" class="case first"><a id="a1" href="/wiki/Marbury_v._Madison" rel="nofollow noopener" lang="en-US" title="Marbury v. Madison">Marbury</a></li>
+		<li id="l2"><span id="s2"><a id="a2" href="https://example.com/wiki/Dred_Scott" lang="en">Dred Scott</a></span></li>
+		<li id="l3" class="case"></li>
+		<li id="l4"></li>
+		<li id="l5" class="last"></li>
+	</ul>
+	<p id="p1"></p>
+	<p id="p2"></p>
+	<span id="s3"></span>
+</div>`
+
+// idsOf returns the ids of the nodes, skipping those without one, such as
+// the html, head and body elements added by the parser.
+func idsOf(nodes []*html.Node) []string {
+	ids := []string{}
+	for _, n := range nodes {
+		if attr := GetAttr(n, "id"); attr != nil {
+			ids = append(ids, attr.Val)
+		}
+	}
+	return ids
+}
+
+func TestCompile(t *testing.T) {
+	doc, err := html.Parse(strings.NewReader(compileDoc))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		selector string
+		expected []string
+	}{
+		// simple selectors
+		{"li", []string{"l1", "l2", "l3", "l4", "l5"}},
+		{"LI", []string{"l1", "l2", "l3", "l4", "l5"}},
+		{"#list", []string{"list"}},
+		{".case", []string{"l1", "l3"}},
+		{"li.case.first", []string{"l1"}},
+		{"div *", []string{"list", "l1", "a1", "l2", "s2", "a2", "l3", "l4", "l5", "p1", "p2", "s3"}},
+
+		// combinators
+		{"ul a", []string{"a1", "a2"}},
+		{"li > a", []string{"a1"}},
+		{"li>a", []string{"a1"}},
+		{"#l1 + li", []string{"l2"}},
+		{"#l3 ~ li", []string{"l4", "l5"}},
+		{"ul ~ p", []string{"p1", "p2"}},
+		{"ul + p", []string{"p1"}},
+		{"div > ul > li > span > a", []string{"a2"}},
+
+		// selector lists
+		{"p, span", []string{"s2", "p1", "p2", "s3"}},
+		{"#p2,#l1 , a", []string{"l1", "a1", "a2", "p2"}},
+
+		// pseudo-classes
+		{"li:first-child", []string{"l1"}},
+		{"li:last-child", []string{"l5"}},
+		{"a:only-child", []string{"a1", "a2"}},
+		{"li:nth-child(odd)", []string{"l1", "l3", "l5"}},
+		{"li:nth-child(even)", []string{"l2", "l4"}},
+		{"li:nth-child(-n+3)", []string{"l1", "l2", "l3"}},
+		{"li:nth-child(2n+1)", []string{"l1", "l3", "l5"}},
+		{"li:nth-child( 2n + 1 )", []string{"l1", "l3", "l5"}},
+		{"li:nth-child(3)", []string{"l3"}},
+		{"li:not(.case)", []string{"l2", "l4", "l5"}},
+		{"li:not(.case, :last-child)", []string{"l2", "l4"}},
+		{"li:not(:nth-child(odd))", []string{"l2", "l4"}},
+
+		// attributes
+		{"[href]", []string{"a1", "a2"}},
+		{"[ lang ]", []string{"a1", "a2"}},
+		{"[lang=en]", []string{"a2"}},
+		{`[lang="en"]`, []string{"a2"}},
+		{"[title='Marbury v. Madison']", []string{"a1"}},
+		{"[rel~=noopener]", []string{"a1"}},
+		{`[rel~="nofollow"]`, []string{"a1"}},
+		{"[rel~=follow]", []string{}},
+		{"[lang|=en]", []string{"a1", "a2"}},
+		{`[lang|="en-US"]`, []string{"a1"}},
+		{"[href^=https]", []string{"a2"}},
+		{`[href^="/wiki/"]`, []string{"a1"}},
+		{"[href$=Scott]", []string{"a2"}},
+		{`[href$="_Madison"]`, []string{"a1"}},
+		{"[href*=wiki]", []string{"a1", "a2"}},
+		{`[href*="example.com"]`, []string{"a2"}},
+		{`[href^=""]`, []string{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.selector, func(t *testing.T) {
+			sel, err := Compile(test.selector)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ids := idsOf(Query(doc, sel))
+			if !reflect.DeepEqual(ids, test.expected) {
+				t.Fatalf("expected %q, got %q", test.expected, ids)
+			}
+		})
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	tests := []string{
+		"",
+		"tr >",
+		"tr +",
+		"tr,",
+		"[a=",
+		"[a",
+		"[a=b",
+		`[a="b]`,
+		"[a!=b]",
+		":nth-child(x)",
+		":nth-child(2x+1)",
+		":nth-child(3",
+		":nth-child",
+		":not(.a",
+		":hover",
+		":first-of-type",
+		"li#",
+		"li.",
+		"li)",
+		"li !",
+		"li > > a",
+	}
+
+	for _, test := range tests {
+		t.Run(test, func(t *testing.T) {
+			if _, err := Compile(test); err == nil {
+				t.Fatalf("expected an error for %q", test)
+			}
+		})
+	}
+}
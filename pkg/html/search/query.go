@@ -2,6 +2,8 @@ package search
 
 import "golang.org/x/net/html"
 
+// Query returns all of the nodes under root, including root itself, that
+// match the selector in document order.
 func Query(
 	root *html.Node,
 	selector Selector,
@@ -24,3 +26,106 @@ func query(
 
 	return results
 }
+
+// QueryFirst returns the first node under root, in document order, that
+// matches the selector, or nil if there is none. Unlike Query, it stops as
+// soon as a match is found.
+func QueryFirst(
+	root *html.Node,
+	selector Selector,
+) *html.Node {
+	it := Iterate(root, selector)
+	if it.Next() {
+		return it.Node()
+	}
+	return nil
+}
+
+// QueryScoped is like Query but does not look for matches within nodes
+// that have already matched. For example, a query for tables returns only
+// the outermost tables and never walks their contents.
+func QueryScoped(
+	root *html.Node,
+	selector Selector,
+) []*html.Node {
+	var results []*html.Node
+	for it := Iterate(root, selector); it.Next(); {
+		results = append(results, it.Node())
+		it.SkipChildren()
+	}
+	return results
+}
+
+// Iterator lazily walks the nodes under a root in document order, stopping
+// at those that match a selector.
+type Iterator struct {
+	root     *html.Node
+	selector Selector
+	last     *html.Node
+	started  bool
+	skip     bool
+}
+
+// Iterate returns an Iterator over the nodes under root, including root
+// itself, that match the selector.
+func Iterate(root *html.Node, selector Selector) *Iterator {
+	return &Iterator{
+		root:     root,
+		selector: selector,
+	}
+}
+
+// Next advances to the next matching node and reports whether there is
+// one.
+func (it *Iterator) Next() bool {
+	for n := it.advance(); n != nil; n = it.advance() {
+		if it.selector(n) {
+			return true
+		}
+	}
+	return false
+}
+
+// Node returns the node found by the last call to Next.
+func (it *Iterator) Node() *html.Node {
+	return it.last
+}
+
+// SkipChildren causes the iterator to move past the descendants of the
+// current node rather than visiting them.
+func (it *Iterator) SkipChildren() {
+	it.skip = true
+}
+
+func (it *Iterator) advance() *html.Node {
+	if !it.started {
+		it.started = true
+		it.last = it.root
+		return it.last
+	}
+
+	n := it.last
+	if n == nil {
+		return nil
+	}
+
+	skip := it.skip
+	it.skip = false
+
+	if !skip && n.FirstChild != nil {
+		it.last = n.FirstChild
+		return it.last
+	}
+
+	for n != it.root && n.NextSibling == nil {
+		n = n.Parent
+	}
+
+	if n == it.root {
+		it.last = nil
+	} else {
+		it.last = n.NextSibling
+	}
+
+	return it.last
+}
@@ -48,6 +48,69 @@ func HasDirectParent(selector Selector) Selector {
 	}
 }
 
+func HasPrevSibling(selector Selector) Selector {
+	return func(n *html.Node) bool {
+		for s := prevElementSibling(n); s != nil; s = prevElementSibling(s) {
+			if selector(s) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func HasDirectPrevSibling(selector Selector) Selector {
+	return func(n *html.Node) bool {
+		if s := prevElementSibling(n); s != nil {
+			return selector(s)
+		}
+		return false
+	}
+}
+
+func HasAttr(name string) Selector {
+	return func(n *html.Node) bool {
+		return GetAttr(n, name) != nil
+	}
+}
+
+func HasAttrMatching(name string, fn func(v string) bool) Selector {
+	return func(n *html.Node) bool {
+		if attr := GetAttr(n, name); attr != nil {
+			return fn(attr.Val)
+		}
+		return false
+	}
+}
+
+// IsNthChild matches elements whose 1-based position among their element
+// siblings is a*n+b for some n >= 0, as in the CSS :nth-child(an+b).
+func IsNthChild(a, b int) Selector {
+	return func(n *html.Node) bool {
+		if n.Type != html.ElementNode || n.Parent == nil {
+			return false
+		}
+
+		pos := 1
+		for s := prevElementSibling(n); s != nil; s = prevElementSibling(s) {
+			pos++
+		}
+
+		if a == 0 {
+			return pos == b
+		}
+
+		d := pos - b
+		return d%a == 0 && d/a >= 0
+	}
+}
+
+func Not(selector Selector) Selector {
+	return func(n *html.Node) bool {
+		return !selector(n)
+	}
+}
+
 func HasAll(first Selector, selectors ...Selector) Selector {
 	return func(n *html.Node) bool {
 		if !first(n) {
@@ -104,3 +167,12 @@ func GetAttr(n *html.Node, name string) *html.Attribute {
 	}
 	return nil
 }
+
+func prevElementSibling(n *html.Node) *html.Node {
+	for s := n.PrevSibling; s != nil; s = s.PrevSibling {
+		if s.Type == html.ElementNode {
+			return s
+		}
+	}
+	return nil
+}
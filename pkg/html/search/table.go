@@ -0,0 +1,250 @@
+package search
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// Link is an anchor found within a table cell.
+type Link struct {
+	Text string
+	Href string
+}
+
+// Cell is a th or td in a table. A cell that spans several rows or
+// columns appears in each of the positions it covers.
+type Cell struct {
+	Node  *html.Node
+	Text  string
+	Links []*Link
+}
+
+// Record is a row of a table keyed by the text of the column headers.
+type Record map[string]*Cell
+
+// Get returns the cell in the column with the given header. If there is no
+// such column, an empty cell is returned.
+func (r Record) Get(header string) *Cell {
+	if c := r[header]; c != nil {
+		return c
+	}
+	return &Cell{}
+}
+
+// Table is the contents of an HTML table.
+type Table struct {
+	Headers []string
+	Rows    []Record
+}
+
+// FindHeader returns the first header that contains any of the given
+// substrings, ignoring case. It is an error if there is no such header,
+// which keeps a renamed or missing column from going unnoticed.
+func (t *Table) FindHeader(substrs ...string) (string, error) {
+	for _, h := range t.Headers {
+		lh := strings.ToLower(h)
+		for _, s := range substrs {
+			if strings.Contains(lh, strings.ToLower(s)) {
+				return h, nil
+			}
+		}
+	}
+
+	return "", fmt.Errorf(
+		"no column matching %q in %q",
+		substrs,
+		t.Headers)
+}
+
+var cellSelector = HasAny(IsElementOf("th"), IsElementOf("td"))
+
+// ReadTable reads a table into records keyed by the text of its headers,
+// with whitespace normalized. Repeated headers are numbered from the
+// second occurrence, e.g. "Score", "Score 2". The header is made up of the
+// rows in the thead or, if there is no thead, the first row if it contains
+// only th cells. Cells that span multiple rows or columns are repeated in
+// each position they cover. Rows of nested tables are not included.
+func ReadTable(table *html.Node) (*Table, error) {
+	if table.Type != html.ElementNode || table.Data != "table" {
+		return nil, fmt.Errorf("expected a table, got <%s>", table.Data)
+	}
+
+	var trs []*html.Node
+	var hdrRows int
+	for _, tr := range rowsOf(table) {
+		if tr.Parent.Data == "thead" {
+			hdrRows++
+		}
+		trs = append(trs, tr)
+	}
+
+	grid := layoutCells(trs)
+
+	if hdrRows == 0 && len(trs) > 0 && allHeaderCells(trs[0]) {
+		hdrRows = 1
+	}
+
+	var ncols int
+	for _, row := range grid {
+		if len(row) > ncols {
+			ncols = len(row)
+		}
+	}
+
+	headers := make([]string, ncols)
+	seen := map[string]int{}
+	for i := range headers {
+		var parts []string
+		var last *Cell
+		for _, row := range grid[:hdrRows] {
+			if i < len(row) && row[i] != nil && row[i] != last && row[i].Text != "" {
+				parts = append(parts, row[i].Text)
+			}
+			if i < len(row) {
+				last = row[i]
+			}
+		}
+
+		h := strings.Join(parts, " ")
+		if h == "" {
+			h = strconv.Itoa(i)
+		}
+
+		// a header spanning several columns is numbered so that each column
+		// has a distinct key.
+		seen[h]++
+		if n := seen[h]; n > 1 {
+			h = fmt.Sprintf("%s %d", h, n)
+		}
+		headers[i] = h
+	}
+
+	rows := make([]Record, 0, len(grid)-hdrRows)
+	for _, row := range grid[hdrRows:] {
+		rec := Record{}
+		for i, c := range row {
+			if c == nil {
+				continue
+			}
+			if _, ok := rec[headers[i]]; !ok {
+				rec[headers[i]] = c
+			}
+		}
+		rows = append(rows, rec)
+	}
+
+	return &Table{
+		Headers: headers,
+		Rows:    rows,
+	}, nil
+}
+
+// rowsOf finds the rows that belong to table, leaving out those of any
+// nested tables. Rows in the thead come first regardless of where it
+// appears.
+func rowsOf(table *html.Node) []*html.Node {
+	var head, body []*html.Node
+	for c := table.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type != html.ElementNode {
+			continue
+		}
+
+		switch c.Data {
+		case "tr":
+			body = append(body, c)
+		case "thead", "tbody", "tfoot":
+			for tr := c.FirstChild; tr != nil; tr = tr.NextSibling {
+				if tr.Type != html.ElementNode || tr.Data != "tr" {
+					continue
+				}
+				if c.Data == "thead" {
+					head = append(head, tr)
+				} else {
+					body = append(body, tr)
+				}
+			}
+		}
+	}
+	return append(head, body...)
+}
+
+func allHeaderCells(tr *html.Node) bool {
+	n := 0
+	for c := tr.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type != html.ElementNode {
+			continue
+		}
+		if c.Data != "th" {
+			return false
+		}
+		n++
+	}
+	return n > 0
+}
+
+func spanOf(n *html.Node, name string) int {
+	if attr := GetAttr(n, name); attr != nil {
+		if v, err := strconv.Atoi(strings.TrimSpace(attr.Val)); err == nil && v > 1 {
+			return v
+		}
+	}
+	return 1
+}
+
+// layoutCells places the cells of each row into a grid, accounting for
+// rowspan and colspan.
+func layoutCells(trs []*html.Node) [][]*Cell {
+	grid := make([][]*Cell, len(trs))
+
+	put := func(r, c int, cell *Cell) {
+		for len(grid[r]) <= c {
+			grid[r] = append(grid[r], nil)
+		}
+		grid[r][c] = cell
+	}
+
+	for r, tr := range trs {
+		col := 0
+		for n := tr.FirstChild; n != nil; n = n.NextSibling {
+			if !cellSelector(n) {
+				continue
+			}
+
+			// skip positions filled by cells spanning from rows above.
+			for col < len(grid[r]) && grid[r][col] != nil {
+				col++
+			}
+
+			cell := newCell(n)
+			rs := spanOf(n, "rowspan")
+			cs := spanOf(n, "colspan")
+			for i := r; i < r+rs && i < len(trs); i++ {
+				for j := col; j < col+cs; j++ {
+					put(i, j, cell)
+				}
+			}
+			col += cs
+		}
+	}
+
+	return grid
+}
+
+func newCell(n *html.Node) *Cell {
+	var links []*Link
+	for _, a := range Query(n, HasAll(IsElementOf("a"), HasAttr("href"))) {
+		links = append(links, &Link{
+			Text: InnerText(a),
+			Href: GetAttr(a, "href").Val,
+		})
+	}
+
+	return &Cell{
+		Node:  n,
+		Text:  InnerText(n),
+		Links: links,
+	}
+}
@@ -0,0 +1,233 @@
+package search
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+)
+
+func readTestTable(t *testing.T, src string) *Table {
+	doc, err := html.Parse(strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	table := QueryFirst(doc, IsElementOf("table"))
+	if table == nil {
+		t.Fatal("no table found")
+	}
+
+	tbl, err := ReadTable(table)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return tbl
+}
+
+// textOf returns the text of every cell in each row, ordered by headers.
+func textOf(tbl *Table) [][]string {
+	rows := [][]string{}
+	for _, rec := range tbl.Rows {
+		var row []string
+		for _, h := range tbl.Headers {
+			row = append(row, rec.Get(h).Text)
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+func TestReadTable(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     string
+		headers []string
+		rows    [][]string
+	}{
+		{
+			name: "header in thead",
+			src: `<table>
+				<thead><tr><td>Year</td><td>Case</td></tr></thead>
+				<tbody><tr><td>1803</td><td>Marbury v. Madison</td></tr></tbody>
+			</table>`,
+			headers: []string{"Year", "Case"},
+			rows: [][]string{
+				{"1803", "Marbury v. Madison"},
+			},
+		},
+		{
+			name: "thead after tbody",
+			src: `<table>
+				<tbody><tr><td>1803</td><td>Marbury v. Madison</td></tr></tbody>
+				<thead><tr><th>Year</th><th>Case</th></tr></thead>
+			</table>`,
+			headers: []string{"Year", "Case"},
+			rows: [][]string{
+				{"1803", "Marbury v. Madison"},
+			},
+		},
+		{
+			name: "header in first row of th",
+			src: `<table>
+				<tr><th> Year </th><th>Case<br>name</th></tr>
+				<tr><td>1803</td><td>Marbury v. Madison</td></tr>
+			</table>`,
+			headers: []string{"Year", "Case name"},
+			rows: [][]string{
+				{"1803", "Marbury v. Madison"},
+			},
+		},
+		{
+			name: "no header",
+			src: `<table>
+				<tr><th>1803</th><td>Marbury v. Madison</td></tr>
+				<tr><th>1857</th><td>Dred Scott v. Sandford</td></tr>
+			</table>`,
+			headers: []string{"0", "1"},
+			rows: [][]string{
+				{"1803", "Marbury v. Madison"},
+				{"1857", "Dred Scott v. Sandford"},
+			},
+		},
+		{
+			name: "rowspan",
+			src: `<table>
+				<tr><th>Overruling</th><th>Year</th><th>Overruled</th></tr>
+				<tr><td rowspan="2">Brown v. Board</td><td rowspan=2>1954</td><td>Plessy v. Ferguson</td></tr>
+				<tr><td>Cumming v. Richmond</td></tr>
+				<tr><td>Mapp v. Ohio</td><td>1961</td><td>Wolf v. Colorado</td></tr>
+			</table>`,
+			headers: []string{"Overruling", "Year", "Overruled"},
+			rows: [][]string{
+				{"Brown v. Board", "1954", "Plessy v. Ferguson"},
+				{"Brown v. Board", "1954", "Cumming v. Richmond"},
+				{"Mapp v. Ohio", "1961", "Wolf v. Colorado"},
+			},
+		},
+		{
+			name: "rowspan past the last row",
+			src: `<table>
+				<tr><th>Case</th><th>Year</th></tr>
+				<tr><td>Marbury v. Madison</td><td rowspan="5">1803</td></tr>
+			</table>`,
+			headers: []string{"Case", "Year"},
+			rows: [][]string{
+				{"Marbury v. Madison", "1803"},
+			},
+		},
+		{
+			name: "colspan in body",
+			src: `<table>
+				<tr><th>Case</th><th>Year</th><th>Note</th></tr>
+				<tr><td>Marbury v. Madison</td><td colspan="2">unknown</td></tr>
+			</table>`,
+			headers: []string{"Case", "Year", "Note"},
+			rows: [][]string{
+				{"Marbury v. Madison", "unknown", "unknown"},
+			},
+		},
+		{
+			name: "multiple header rows",
+			src: `<table>
+				<thead>
+					<tr><th rowspan="2">Case</th><th colspan="2">Votes</th></tr>
+					<tr><th>Majority</th><th>Minority</th></tr>
+				</thead>
+				<tr><td>Dred Scott v. Sandford</td><td>7</td><td>2</td></tr>
+			</table>`,
+			headers: []string{"Case", "Votes Majority", "Votes Minority"},
+			rows: [][]string{
+				{"Dred Scott v. Sandford", "7", "2"},
+			},
+		},
+		{
+			name: "duplicate headers",
+			src: `<table>
+				<tr><th>Case</th><th colspan="2">Score</th><th>Score</th></tr>
+				<tr><td>Roe v. Wade</td><td>7</td><td>2</td><td>9</td></tr>
+			</table>`,
+			headers: []string{"Case", "Score", "Score 2", "Score 3"},
+			rows: [][]string{
+				{"Roe v. Wade", "7", "2", "9"},
+			},
+		},
+		{
+			name: "nested table",
+			src: `<table>
+				<tr><th>Case</th><th>Votes</th></tr>
+				<tr>
+					<td>Roe v. Wade</td>
+					<td><table><tr><td>7-2</td></tr></table></td>
+				</tr>
+			</table>`,
+			headers: []string{"Case", "Votes"},
+			rows: [][]string{
+				{"Roe v. Wade", "7-2"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tbl := readTestTable(t, test.src)
+
+			if !reflect.DeepEqual(tbl.Headers, test.headers) {
+				t.Fatalf("expected headers %q, got %q", test.headers, tbl.Headers)
+			}
+
+			if rows := textOf(tbl); !reflect.DeepEqual(rows, test.rows) {
+				t.Fatalf("expected rows %q, got %q", test.rows, rows)
+			}
+		})
+	}
+}
+
+func TestReadTableLinks(t *testing.T) {
+	tbl := readTestTable(t, `<table>
+		<tr><th>Case</th></tr>
+		<tr><td><a href="/wiki/Brown_v._Board">Brown v. Board</a> and <a>Briggs</a></td></tr>
+	</table>`)
+
+	links := tbl.Rows[0].Get("Case").Links
+	if len(links) != 1 {
+		t.Fatalf("expected 1 link, got %d", len(links))
+	}
+
+	if l := links[0]; l.Text != "Brown v. Board" || l.Href != "/wiki/Brown_v._Board" {
+		t.Fatalf("expected link to Brown v. Board, got %+v", l)
+	}
+
+	if c := tbl.Rows[0].Get("Year"); c.Node != nil || c.Text != "" {
+		t.Fatalf("expected an empty cell for a missing column, got %+v", c)
+	}
+}
+
+func TestReadTableRejectsOtherElements(t *testing.T) {
+	doc, err := html.Parse(strings.NewReader(`<div></div>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := ReadTable(QueryFirst(doc, IsElementOf("div"))); err == nil {
+		t.Fatal("expected an error for a div")
+	}
+}
+
+func TestFindHeader(t *testing.T) {
+	tbl := &Table{Headers: []string{"Overruling decision", "Year overruled"}}
+
+	h, err := tbl.FindHeader("YEAR")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h != "Year overruled" {
+		t.Fatalf("expected Year overruled, got %q", h)
+	}
+
+	if _, err := tbl.FindHeader("justice", "nominee"); err == nil {
+		t.Fatal("expected an error for a missing header")
+	}
+}
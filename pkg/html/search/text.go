@@ -0,0 +1,36 @@
+package search
+
+import (
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// InnerText returns the text within n with runs of whitespace, including
+// non-breaking spaces, collapsed to a single space and trimmed from both
+// ends.
+func InnerText(n *html.Node) string {
+	var buf strings.Builder
+	collectText(n, &buf)
+	return normalizeSpace(buf.String())
+}
+
+func collectText(n *html.Node, buf *strings.Builder) {
+	switch n.Type {
+	case html.TextNode:
+		buf.WriteString(n.Data)
+	case html.ElementNode:
+		if n.Data == "br" {
+			buf.WriteByte(' ')
+		}
+	}
+
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		collectText(c, buf)
+	}
+}
+
+func normalizeSpace(s string) string {
+	s = strings.ReplaceAll(s, "\u00a0", " ")
+	return strings.TrimSpace(whitespacePattern.ReplaceAllString(s, " "))
+}
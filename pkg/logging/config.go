@@ -0,0 +1,166 @@
+package logging
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	FormatConsole = "console"
+	FormatJSON    = "json"
+)
+
+// Config describes how the logger is built. The zero value is not useful;
+// use DefaultConfig or Register to get the defaults.
+type Config struct {
+	// Format is either FormatConsole, which is meant for people, or
+	// FormatJSON, which is meant for log shippers.
+	Format string
+
+	// Level is the minimum level that is logged.
+	Level zapcore.Level
+
+	// Levels overrides Level for named loggers. A name also covers the
+	// loggers beneath it, so "data" applies to "data.scotusdb".
+	Levels map[string]zapcore.Level
+
+	// SampleInitial and SampleThereafter limit repetitive logging. Each
+	// second, the first SampleInitial entries with the same level and
+	// message are logged and then every SampleThereafter-th one after that,
+	// or none of them if it is zero. Sampling is off when SampleInitial is
+	// zero.
+	SampleInitial    int
+	SampleThereafter int
+
+	// Outputs are the paths where logs are written. "stdout" and "stderr"
+	// are the standard streams; anything else is a file.
+	Outputs []string
+
+	// Rotation applies to the files in Outputs.
+	Rotation Rotation
+}
+
+// Rotation describes when log files are rotated.
+type Rotation struct {
+	// MaxSizeMB is the size a file may reach before it is rotated. Files
+	// are never rotated when it is zero.
+	MaxSizeMB int
+
+	// MaxBackups is the number of rotated files to keep.
+	MaxBackups int
+}
+
+func DefaultConfig() *Config {
+	return &Config{
+		Format:           FormatConsole,
+		Level:            zapcore.DebugLevel,
+		SampleThereafter: 100,
+		Outputs:          []string{"stderr"},
+	}
+}
+
+// Register resets c to DefaultConfig and adds flags for each of its
+// fields.
+func (c *Config) Register(fs *flag.FlagSet) {
+	*c = *DefaultConfig()
+
+	fs.StringVar(
+		&c.Format,
+		"log.format",
+		c.Format,
+		"the log encoding (console or json)")
+
+	fs.Var(
+		&c.Level,
+		"log.level",
+		"the minimum level to log (debug, info, warn, error)")
+
+	fs.Func(
+		"log.levels",
+		"per-logger level overrides, e.g. data=warn,web=debug",
+		func(v string) error {
+			levels, err := parseLevels(v)
+			if err != nil {
+				return err
+			}
+			c.Levels = levels
+			return nil
+		})
+
+	fs.IntVar(
+		&c.SampleInitial,
+		"log.sample-initial",
+		c.SampleInitial,
+		"entries logged each second with the same message before sampling (0 disables sampling)")
+
+	fs.IntVar(
+		&c.SampleThereafter,
+		"log.sample-thereafter",
+		c.SampleThereafter,
+		"once sampling, log every nth entry with the same message (0 logs none)")
+
+	fs.Func(
+		"log.outputs",
+		"comma separated paths to write logs to, stdout and stderr included (default stderr)",
+		func(v string) error {
+			c.Outputs = splitList(v)
+			return nil
+		})
+
+	fs.IntVar(
+		&c.Rotation.MaxSizeMB,
+		"log.max-size-mb",
+		c.Rotation.MaxSizeMB,
+		"the size in MB at which log files are rotated (0 disables rotation)")
+
+	fs.IntVar(
+		&c.Rotation.MaxBackups,
+		"log.max-backups",
+		c.Rotation.MaxBackups,
+		"the number of rotated log files to keep")
+}
+
+func (c *Config) validate() error {
+	switch c.Format {
+	case FormatConsole, FormatJSON:
+	default:
+		return fmt.Errorf("unknown log format: %s", c.Format)
+	}
+
+	if len(c.Outputs) == 0 {
+		return fmt.Errorf("no log outputs")
+	}
+
+	return nil
+}
+
+func splitList(v string) []string {
+	var items []string
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// parseLevels parses a comma separated list of name=level pairs.
+func parseLevels(v string) (map[string]zapcore.Level, error) {
+	levels := map[string]zapcore.Level{}
+	for _, item := range splitList(v) {
+		name, level, ok := strings.Cut(item, "=")
+		if !ok {
+			return nil, fmt.Errorf("expected name=level, got %q", item)
+		}
+
+		var l zapcore.Level
+		if err := l.Set(strings.TrimSpace(level)); err != nil {
+			return nil, err
+		}
+		levels[strings.TrimSpace(name)] = l
+	}
+	return levels, nil
+}
@@ -0,0 +1,74 @@
+package logging
+
+import (
+	"strings"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// levelCore filters entries by the level configured for the name of the
+// logger that wrote them, falling back to a default level.
+type levelCore struct {
+	zapcore.Core
+	level  zapcore.Level
+	levels map[string]zapcore.Level
+}
+
+func newLevelCore(
+	core zapcore.Core,
+	level zapcore.Level,
+	levels map[string]zapcore.Level,
+) zapcore.Core {
+	if len(levels) == 0 {
+		return core
+	}
+	return &levelCore{
+		Core:   core,
+		level:  level,
+		levels: levels,
+	}
+}
+
+// minLevel is the lowest level that any logger may write.
+func minLevel(level zapcore.Level, levels map[string]zapcore.Level) zapcore.Level {
+	for _, l := range levels {
+		if l < level {
+			level = l
+		}
+	}
+	return level
+}
+
+// levelFor finds the level for the most specific name that matches the
+// logger's name.
+func (c *levelCore) levelFor(name string) zapcore.Level {
+	for {
+		if l, ok := c.levels[name]; ok {
+			return l
+		}
+
+		i := strings.LastIndexByte(name, '.')
+		if i < 0 {
+			return c.level
+		}
+		name = name[:i]
+	}
+}
+
+func (c *levelCore) With(fields []zapcore.Field) zapcore.Core {
+	return &levelCore{
+		Core:   c.Core.With(fields),
+		level:  c.level,
+		levels: c.levels,
+	}
+}
+
+func (c *levelCore) Check(
+	e zapcore.Entry,
+	ce *zapcore.CheckedEntry,
+) *zapcore.CheckedEntry {
+	if e.Level < c.levelFor(e.LoggerName) {
+		return ce
+	}
+	return c.Core.Check(e, ce)
+}
@@ -25,18 +25,24 @@ func L(ctx context.Context) *zap.Logger {
 // 	ctx context.Context,
 // 	timeout time.Duration,
 // ) (context.Context, context.CancelFunc, *zap.Logger) {
-// 	lg := L(ctx).With(zap.String("req_id", requestID()))
+// 	lg := L(ctx).With(zap.String("req_id", NewRequestID()))
 // 	ctx = context.WithValue(ctx, loggerKey, lg)
 // 	ctx, done := context.WithTimeout(ctx, timeout)
 // 	return ctx, done, lg
 // }
 
-func ForRequest(ctx context.Context) (context.Context, *zap.Logger) {
-	lg := L(ctx).With(zap.String("req_id", requestID()))
+// ForRequest returns a context and logger that tag every entry with the
+// given request id. If id is empty, a random one is used.
+func ForRequest(ctx context.Context, id string) (context.Context, *zap.Logger) {
+	if id == "" {
+		id = NewRequestID()
+	}
+	lg := L(ctx).With(zap.String("req_id", id))
 	return context.WithValue(ctx, loggerKey, lg), lg
 }
 
-func requestID() string {
+// NewRequestID returns a random id for a request.
+func NewRequestID() string {
 	var buf [8]byte
 	if _, err := rand.Read(buf[:]); err != nil {
 		panic(err)
@@ -0,0 +1,109 @@
+package logging
+
+import (
+	"fmt"
+	"os"
+	"sync"
+)
+
+// rotatingFile is a log file that is renamed to path.1, path.2, ... once it
+// grows past a maximum size, keeping a fixed number of the old files.
+type rotatingFile struct {
+	lck        sync.Mutex
+	path       string
+	maxSize    int64
+	maxBackups int
+	f          *os.File
+	size       int64
+}
+
+func openRotatingFile(path string, r Rotation) (*rotatingFile, error) {
+	f := &rotatingFile{
+		path:       path,
+		maxSize:    int64(r.MaxSizeMB) * 1024 * 1024,
+		maxBackups: r.MaxBackups,
+	}
+
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+func (f *rotatingFile) open() error {
+	w, err := os.OpenFile(f.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+
+	s, err := w.Stat()
+	if err != nil {
+		w.Close()
+		return err
+	}
+
+	f.f = w
+	f.size = s.Size()
+	return nil
+}
+
+func (f *rotatingFile) backup(i int) string {
+	return fmt.Sprintf("%s.%d", f.path, i)
+}
+
+func (f *rotatingFile) rotate() error {
+	if err := f.f.Close(); err != nil {
+		return err
+	}
+
+	if f.maxBackups <= 0 {
+		if err := os.Remove(f.path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return f.open()
+	}
+
+	if err := os.Remove(f.backup(f.maxBackups)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	for i := f.maxBackups - 1; i > 0; i-- {
+		if err := os.Rename(f.backup(i), f.backup(i+1)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	if err := os.Rename(f.path, f.backup(1)); err != nil {
+		return err
+	}
+
+	return f.open()
+}
+
+func (f *rotatingFile) Write(b []byte) (int, error) {
+	f.lck.Lock()
+	defer f.lck.Unlock()
+
+	if f.maxSize > 0 && f.size > 0 && f.size+int64(len(b)) > f.maxSize {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := f.f.Write(b)
+	f.size += int64(n)
+	return n, err
+}
+
+func (f *rotatingFile) Sync() error {
+	f.lck.Lock()
+	defer f.lck.Unlock()
+	return f.f.Sync()
+}
+
+func (f *rotatingFile) Close() error {
+	f.lck.Lock()
+	defer f.lck.Unlock()
+	return f.f.Close()
+}
@@ -1,28 +1,119 @@
 package logging
 
 import (
+	"io"
+	"os"
+	"time"
+
+	"go.uber.org/multierr"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
 
-func Setup() (*zap.Logger, error) {
-	c := zap.NewDevelopmentConfig()
-	c.EncoderConfig.EncodeDuration = zapcore.StringDurationEncoder
-	c.EncoderConfig.EncodeTime = zapcore.RFC3339TimeEncoder
+func newEncoder(format string) zapcore.Encoder {
+	if format == FormatJSON {
+		c := zap.NewProductionEncoderConfig()
+		c.EncodeDuration = zapcore.StringDurationEncoder
+		c.EncodeTime = zapcore.RFC3339TimeEncoder
+		return zapcore.NewJSONEncoder(c)
+	}
+
+	c := zap.NewDevelopmentEncoderConfig()
+	c.EncodeDuration = zapcore.StringDurationEncoder
+	c.EncodeTime = zapcore.RFC3339TimeEncoder
+	return zapcore.NewConsoleEncoder(c)
+}
+
+// openOutputs opens each of the outputs in cfg. The files among them are
+// also returned so that they can be closed.
+func openOutputs(cfg *Config) (zapcore.WriteSyncer, []io.Closer, error) {
+	var ws []zapcore.WriteSyncer
+	var files []io.Closer
+	for _, path := range cfg.Outputs {
+		switch path {
+		case "stdout":
+			ws = append(ws, zapcore.Lock(os.Stdout))
+		case "stderr":
+			ws = append(ws, zapcore.Lock(os.Stderr))
+		default:
+			f, err := openRotatingFile(path, cfg.Rotation)
+			if err != nil {
+				closeAll(files)
+				return nil, nil, err
+			}
+			ws = append(ws, f)
+			files = append(files, f)
+		}
+	}
+	return zapcore.NewMultiWriteSyncer(ws...), files, nil
+}
+
+func closeAll(files []io.Closer) error {
+	var errs error
+	for _, f := range files {
+		errs = multierr.Append(errs, f.Close())
+	}
+	return errs
+}
+
+// Setup builds a logger as described by cfg and installs it as zap's
+// global logger. A nil cfg uses DefaultConfig. The returned function
+// flushes the logger and closes any files it writes to; it should be
+// called before the process exits.
+func Setup(cfg *Config) (*zap.Logger, func() error, error) {
+	if cfg == nil {
+		cfg = DefaultConfig()
+	}
+
+	if err := cfg.validate(); err != nil {
+		return nil, nil, err
+	}
 
-	l, err := c.Build()
+	out, files, err := openOutputs(cfg)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	core := zapcore.NewCore(
+		newEncoder(cfg.Format),
+		out,
+		minLevel(cfg.Level, cfg.Levels))
+	core = newLevelCore(core, cfg.Level, cfg.Levels)
+
+	if cfg.SampleInitial > 0 {
+		core = zapcore.NewSamplerWithOptions(
+			core,
+			time.Second,
+			cfg.SampleInitial,
+			cfg.SampleThereafter)
 	}
 
+	opts := []zap.Option{
+		zap.AddCaller(),
+		zap.ErrorOutput(zapcore.Lock(os.Stderr)),
+	}
+	if cfg.Format == FormatJSON {
+		opts = append(opts, zap.AddStacktrace(zapcore.ErrorLevel))
+	} else {
+		opts = append(opts, zap.Development(), zap.AddStacktrace(zapcore.WarnLevel))
+	}
+
+	l := zap.New(core, opts...)
+
 	zap.ReplaceGlobals(l)
-	return l, nil
+
+	return l, func() error {
+		// syncing the standard streams fails on some platforms, so only
+		// the files are considered.
+		l.Sync()
+		return closeAll(files)
+	}, nil
 }
 
-func MustSetup() *zap.Logger {
-	lg, err := Setup()
+func MustSetup(cfg *Config) (*zap.Logger, func() error) {
+	lg, done, err := Setup(cfg)
 	if err != nil {
 		panic(err)
 	}
-	return lg
+	return lg, done
 }
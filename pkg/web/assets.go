@@ -1,17 +1,346 @@
 package web
 
 import (
+	"bytes"
+	"crypto/sha256"
 	"embed"
+	"encoding/hex"
+	"io"
 	"io/fs"
+	"mime"
 	"net/http"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+	"time"
 )
 
 //go:embed dist
 var assets embed.FS
 
-func getAssetsFS(dir string) (http.FileSystem, error) {
+const (
+	// immutableCacheControl is for fingerprinted URLs, whose content can
+	// never change.
+	immutableCacheControl = "public, max-age=31536000, immutable"
+
+	// htmlCacheControl keeps pages, which refer to the fingerprinted URLs,
+	// fresh within a minute of a deploy.
+	htmlCacheControl = "public, max-age=60"
+
+	// noCacheControl is for everything else, which must be revalidated.
+	noCacheControl = "no-cache"
+)
+
+// contentTypes fills in types that may be missing from the system's MIME
+// database.
+var contentTypes = map[string]string{
+	".css":   "text/css; charset=utf-8",
+	".html":  "text/html; charset=utf-8",
+	".ico":   "image/x-icon",
+	".js":    "text/javascript; charset=utf-8",
+	".json":  "application/json",
+	".map":   "application/json",
+	".svg":   "image/svg+xml",
+	".txt":   "text/plain; charset=utf-8",
+	".woff":  "font/woff",
+	".woff2": "font/woff2",
+}
+
+func contentTypeOf(name string) string {
+	ext := path.Ext(name)
+	if t, ok := contentTypes[ext]; ok {
+		return t
+	}
+	if t := mime.TypeByExtension(ext); t != "" {
+		return t
+	}
+	return "application/octet-stream"
+}
+
+// gzipSuffix is the suffix of the gzip compressed variants of assets,
+// which are built alongside them.
+const gzipSuffix = ".gz"
+
+func isCompressedVariant(name string) bool {
+	return strings.HasSuffix(name, gzipSuffix)
+}
+
+// acceptsEncoding reports whether the request allows the given content
+// coding.
+func acceptsEncoding(r *http.Request, enc string) bool {
+	for _, part := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if !strings.EqualFold(strings.TrimSpace(name), enc) {
+			continue
+		}
+		q := strings.ReplaceAll(strings.TrimSpace(params), " ", "")
+		return q != "q=0" && q != "q=0.0" && q != "q=0.00" && q != "q=0.000"
+	}
+	return false
+}
+
+// asset is a file in the manifest.
+type asset struct {
+	name   string
+	hashed string
+	hash   string
+
+	// body holds HTML with its references rewritten to fingerprinted URLs.
+	// It is nil for other assets, which are served from the file system.
+	body []byte
+}
+
+// fingerprint inserts the hash before the extension, e.g. a/index.js
+// becomes a/index.0123456789.js.
+func fingerprint(name, hash string) string {
+	ext := path.Ext(name)
+	return strings.TrimSuffix(name, ext) + "." + hash + ext
+}
+
+func hashOf(b []byte) string {
+	h := sha256.Sum256(b)
+	return hex.EncodeToString(h[:])[:10]
+}
+
+// Manifest maps assets to content-hashed URLs. The HTML pages, which can't
+// have hashed URLs of their own, have their references to other assets
+// rewritten to the hashed URLs.
+type Manifest struct {
+	byName   map[string]*asset
+	byHashed map[string]*asset
+}
+
+// Lookup returns the fingerprinted path for the asset with the given path,
+// e.g. "a/index.js".
+func (m *Manifest) Lookup(name string) (string, bool) {
+	if a := m.byName[name]; a != nil && a.hashed != "" {
+		return a.hashed, true
+	}
+	return "", false
+}
+
+var refPattern = regexp.MustCompile(`(\s(?:src|href)=")([^"]*)(")`)
+
+// rewriteRefs replaces the references in an HTML page to assets in the
+// manifest with their fingerprinted URLs. Any query string, like the
+// cache-busting ones the pages used to carry, is dropped.
+func (m *Manifest) rewriteRefs(page string, b []byte) []byte {
+	return refPattern.ReplaceAllFunc(b, func(attr []byte) []byte {
+		parts := refPattern.FindSubmatch(attr)
+		ref := string(parts[2])
+		if ref == "" || strings.Contains(ref, "://") || strings.HasPrefix(ref, "//") ||
+			strings.HasPrefix(ref, "data:") || strings.HasPrefix(ref, "#") {
+			return attr
+		}
+
+		u := ref
+		if i := strings.IndexAny(u, "?#"); i >= 0 {
+			u = u[:i]
+		}
+
+		name := strings.TrimPrefix(u, "/")
+		if !strings.HasPrefix(u, "/") {
+			name = path.Join(path.Dir(page), u)
+		}
+
+		hashed, ok := m.Lookup(name)
+		if !ok {
+			return attr
+		}
+
+		ref = path.Join(path.Dir(u), path.Base(hashed))
+		if strings.HasPrefix(u, "/") {
+			ref = "/" + hashed
+		}
+
+		var buf bytes.Buffer
+		buf.Write(parts[1])
+		buf.WriteString(ref)
+		buf.Write(parts[3])
+		return buf.Bytes()
+	})
+}
+
+// NewManifest hashes every asset in fsys. Precompressed variants are left
+// out, as they are found through the files they compress.
+func NewManifest(fsys fs.FS) (*Manifest, error) {
+	m := &Manifest{
+		byName:   map[string]*asset{},
+		byHashed: map[string]*asset{},
+	}
+
+	var pages []string
+	if err := fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || isCompressedVariant(name) {
+			return err
+		}
+
+		if path.Ext(name) == ".html" {
+			pages = append(pages, name)
+			return nil
+		}
+
+		b, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return err
+		}
+
+		a := &asset{
+			name: name,
+			hash: hashOf(b),
+		}
+		a.hashed = fingerprint(name, a.hash)
+		m.byName[name] = a
+		m.byHashed[a.hashed] = a
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	// pages are rewritten once all of the assets they may refer to are
+	// known.
+	for _, name := range pages {
+		b, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return nil, err
+		}
+
+		b = m.rewriteRefs(name, b)
+		m.byName[name] = &asset{
+			name: name,
+			hash: hashOf(b),
+			body: b,
+		}
+	}
+
+	return m, nil
+}
+
+// assetServer serves the files in fsys. With a manifest, assets are also
+// served under their fingerprinted URLs, which are cached indefinitely.
+// Without one, as when serving the output of webpack in dev mode,
+// everything must be revalidated.
+type assetServer struct {
+	fsys     fs.FS
+	manifest *Manifest
+	fallback http.Handler
+}
+
+func newAssetServer(fsys fs.FS, manifest *Manifest) *assetServer {
+	return &assetServer{
+		fsys:     fsys,
+		manifest: manifest,
+		fallback: fileServer(http.FS(fsys)),
+	}
+}
+
+// find locates the asset for a request path, which may refer to a
+// directory with an index.html.
+func (s *assetServer) find(urlPath string) (string, *asset, bool) {
+	name := strings.TrimPrefix(path.Clean("/"+urlPath), "/")
+	if name == "" || strings.HasSuffix(urlPath, "/") {
+		name = path.Join(name, "index.html")
+	}
+
+	if m := s.manifest; m != nil {
+		if a := m.byHashed[name]; a != nil {
+			return a.name, a, true
+		} else if a := m.byName[name]; a != nil {
+			return a.name, a, false
+		}
+		return "", nil, false
+	}
+
+	if fi, err := fs.Stat(s.fsys, name); err != nil || fi.IsDir() {
+		return "", nil, false
+	}
+
+	return name, nil, false
+}
+
+// open opens the gzip compressed variant of name if there is one and the
+// client accepts it, otherwise it opens name itself.
+func (s *assetServer) open(r *http.Request, name string) (fs.File, string, error) {
+	if acceptsEncoding(r, "gzip") {
+		if f, err := s.fsys.Open(name + gzipSuffix); err == nil {
+			return f, "gzip", nil
+		}
+	}
+
+	f, err := s.fsys.Open(name)
+	return f, "", err
+}
+
+func (s *assetServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	name, a, hashed := s.find(r.URL.Path)
+	if name == "" {
+		// directories without an index and missing files.
+		s.fallback.ServeHTTP(w, r)
+		return
+	}
+
+	h := w.Header()
+	h.Set("Content-Type", contentTypeOf(name))
+
+	switch {
+	case hashed:
+		h.Set("Cache-Control", immutableCacheControl)
+	case path.Ext(name) == ".html" && s.manifest != nil:
+		h.Set("Cache-Control", htmlCacheControl)
+	default:
+		h.Set("Cache-Control", noCacheControl)
+	}
+
+	if a != nil {
+		h.Set("ETag", `"`+a.hash+`"`)
+	}
+
+	if a != nil && a.body != nil {
+		http.ServeContent(w, r, name, time.Time{}, bytes.NewReader(a.body))
+		return
+	}
+
+	f, enc, err := s.open(r, name)
+	if err != nil {
+		s.fallback.ServeHTTP(w, r)
+		return
+	}
+	defer f.Close()
+
+	h.Add("Vary", "Accept-Encoding")
+	if enc != "" {
+		h.Set("Content-Encoding", enc)
+		if a != nil {
+			h.Set("ETag", `"`+a.hash+"-"+enc+`"`)
+		}
+	}
+
+	rs, ok := f.(io.ReadSeeker)
+	if !ok {
+		b, err := io.ReadAll(f)
+		if err != nil {
+			sendErr(ContextFrom(w), w, r, http.StatusInternalServerError, "internal server error")
+			return
+		}
+		rs = bytes.NewReader(b)
+	}
+
+	// embedded files have no modification time, so only files on disk get
+	// Last-Modified.
+	var mt time.Time
+	if fi, err := f.Stat(); err == nil {
+		mt = fi.ModTime()
+	}
+
+	http.ServeContent(w, r, name, mt, rs)
+}
+
+// getAssetsHandler serves the assets from dir or, when dir is empty, the
+// ones embedded in the binary with fingerprinted URLs.
+func getAssetsHandler(dir string) (http.Handler, error) {
 	if dir != "" {
-		return http.Dir(dir), nil
+		return newAssetServer(os.DirFS(dir), nil), nil
 	}
 
 	s, err := fs.Sub(assets, "dist")
@@ -19,5 +348,10 @@ func getAssetsFS(dir string) (http.FileSystem, error) {
 		return nil, err
 	}
 
-	return http.FS(s), nil
+	m, err := NewManifest(s)
+	if err != nil {
+		return nil, err
+	}
+
+	return newAssetServer(s, m), nil
 }
@@ -1,11 +1,41 @@
 package web
 
 import (
+	"sync/atomic"
+	"time"
+
 	"github.com/kellegous/scotus/pkg/build"
 	"github.com/kellegous/scotus/pkg/data"
 )
 
 type Data struct {
+	Model      *data.Model
+	Validation *data.Report
+
+	// LoadDuration is how long it took to load the model.
+	LoadDuration time.Duration
+
+	// Sources are the modification times of the files the model was
+	// loaded from.
+	Sources map[string]time.Time
+}
+
+// State holds the Data once it has been loaded. The server starts serving
+// before then so that it can report that it is alive, but not ready.
+type State struct {
+	// Build describes the server, which is known from the start.
 	Build *build.Info
-	Model *data.Model
+
+	v atomic.Value
+}
+
+// SetData makes d available to the server, which is then ready.
+func (s *State) SetData(d *Data) {
+	s.v.Store(d)
+}
+
+// Data returns the data, or nil if it hasn't been loaded yet.
+func (s *State) Data() *Data {
+	d, _ := s.v.Load().(*Data)
+	return d
 }
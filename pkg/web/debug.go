@@ -0,0 +1,152 @@
+package web
+
+import (
+	"context"
+	"crypto/subtle"
+	"expvar"
+	"net"
+	"net/http"
+	"net/http/pprof"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// DebugTokenHeader carries the token that grants access to the debug
+// endpoints when they are served alongside the rest of the server.
+const DebugTokenHeader = "X-Debug-Token"
+
+// Debug configures the endpoints under /api/debug/ that expose the
+// internals of the server: pprof, expvar and the effective configuration.
+// They are off unless they are served on their own listener with
+// ServeDebug, which is meant to be bound to a private address, or a Token
+// is set, in which case Serve also exposes them to requests that carry it.
+type Debug struct {
+	// Token is required in the X-Debug-Token header, or as a bearer token,
+	// for requests to the debug endpoints.
+	Token string
+
+	// Config is the configuration of the server, with any secrets already
+	// redacted.
+	Config map[string]string
+}
+
+func (d *Debug) authorized(r *http.Request) bool {
+	if d.Token == "" {
+		return true
+	}
+
+	t := r.Header.Get(DebugTokenHeader)
+	if t == "" {
+		t = strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	}
+
+	return subtle.ConstantTimeCompare([]byte(t), []byte(d.Token)) == 1
+}
+
+func (d *Debug) guard(fn http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !d.authorized(r) {
+			sendErr(ContextFrom(w), w, r, http.StatusForbidden, "forbidden")
+			return
+		}
+		fn(w, r)
+	}
+}
+
+var publishOnce sync.Once
+
+// publishVars adds the model statistics to expvar. expvar only allows a
+// name to be published once, so the first state wins.
+func publishVars(state *State) {
+	publishOnce.Do(func() {
+		expvar.Publish("goroutines", expvar.Func(func() interface{} {
+			return runtime.NumGoroutine()
+		}))
+
+		expvar.Publish("model", expvar.Func(func() interface{} {
+			data := state.Data()
+			if data == nil {
+				return nil
+			}
+
+			var cases, votes int
+			for _, t := range data.Model.SCOTUSDBCases {
+				cases += len(t.Cases)
+				for _, c := range t.Cases {
+					votes += len(c.Votes)
+				}
+			}
+
+			return map[string]interface{}{
+				"terms":         len(data.Model.SCOTUSDBCases),
+				"cases":         cases,
+				"votes":         votes,
+				"tables":        data.Model.Sizes(),
+				"anomalies":     data.Validation.Total(),
+				"load-duration": data.LoadDuration.String(),
+			}
+		}))
+	})
+}
+
+// servePprof serves the pprof index and profiles under /api/debug/pprof/.
+// pprof.Index only finds profiles under /debug/pprof/, so the named
+// profiles are dispatched here.
+func servePprof(w http.ResponseWriter, r *http.Request) {
+	switch name := strings.TrimPrefix(r.URL.Path, "/api/debug/pprof/"); name {
+	case "":
+		pprof.Index(w, r)
+	case "cmdline":
+		pprof.Cmdline(w, r)
+	case "profile":
+		pprof.Profile(w, r)
+	case "symbol":
+		pprof.Symbol(w, r)
+	case "trace":
+		pprof.Trace(w, r)
+	default:
+		pprof.Handler(name).ServeHTTP(w, r)
+	}
+}
+
+func (d *Debug) register(m *Mux, state *State) {
+	publishVars(state)
+
+	m.Handle("/api/debug/pprof/", d.guard(servePprof))
+
+	m.Get(
+		"/api/debug/vars",
+		d.guard(expvar.Handler().ServeHTTP))
+
+	m.Get(
+		"/api/debug/config",
+		d.guard(func(w http.ResponseWriter, r *http.Request) {
+			sendJSONOK(ContextFrom(w), w, d.Config)
+		}))
+}
+
+// ServeDebug serves only the debug endpoints, along with the build info,
+// on l.
+func ServeDebug(
+	ctx context.Context,
+	l net.Listener,
+	state *State,
+	d *Debug,
+) error {
+	m := NewMux(ctx)
+
+	m.Get(
+		"/api/debug/build",
+		func(w http.ResponseWriter, r *http.Request) {
+			sendJSONOK(ContextFrom(w), w, state.Build)
+		})
+
+	d.register(m, state)
+
+	m.Get("/", func(w http.ResponseWriter, r *http.Request) {
+		sendNotFound(ContextFrom(w), w, r)
+	})
+
+	return http.Serve(l, m)
+}
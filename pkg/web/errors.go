@@ -0,0 +1,124 @@
+package web
+
+import (
+	"context"
+	"html/template"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/kellegous/scotus/pkg/logging"
+
+	"go.uber.org/zap"
+)
+
+var errorPage = template.Must(template.New("error").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Status}} {{.Text}}</title>
+</head>
+<body>
+<h1>{{.Status}} {{.Text}}</h1>
+<p>{{.Message}}</p>
+{{if .RequestID}}<p><small>request {{.RequestID}}</small></p>{{end}}
+</body>
+</html>
+`))
+
+// isAPI reports whether r is for the JSON API rather than for a page or
+// asset.
+func isAPI(r *http.Request) bool {
+	return strings.HasPrefix(r.URL.Path, "/api/")
+}
+
+func sendHTMLErr(
+	ctx context.Context,
+	w http.ResponseWriter,
+	status int,
+	msg string,
+) {
+	w.Header().Set("Content-Type", "text/html;charset=utf-8")
+	w.WriteHeader(status)
+	if err := errorPage.Execute(w, struct {
+		Status    int
+		Text      string
+		Message   string
+		RequestID string
+	}{
+		Status:    status,
+		Text:      http.StatusText(status),
+		Message:   msg,
+		RequestID: w.Header().Get(RequestIDHeader),
+	}); err != nil {
+		logging.L(ctx).Named("web").Info("unable to send error page",
+			zap.Error(err))
+	}
+}
+
+// sendErr responds with JSON for API requests and with an HTML page for
+// everything else.
+func sendErr(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+	status int,
+	msg string,
+) {
+	if isAPI(r) {
+		sendJSONErr(ctx, w, status, msg)
+	} else {
+		sendHTMLErr(ctx, w, status, msg)
+	}
+}
+
+func sendNotFound(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+	sendErr(ctx, w, r, http.StatusNotFound, "not found")
+}
+
+// allowMethods responds with 405 to requests with methods other than the
+// ones given.
+func allowMethods(h http.Handler, methods ...string) http.Handler {
+	allow := strings.Join(methods, ", ")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for _, m := range methods {
+			if r.Method == m {
+				h.ServeHTTP(w, r)
+				return
+			}
+		}
+
+		w.Header().Set("Allow", allow)
+		sendErr(
+			ContextFrom(w),
+			w,
+			r,
+			http.StatusMethodNotAllowed,
+			"method not allowed")
+	})
+}
+
+// fileServer is http.FileServer, except that missing files get the same
+// not found response as the rest of the server.
+func fileServer(fs http.FileSystem) http.Handler {
+	h := http.FileServer(fs)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if isAPI(r) {
+			sendNotFound(ContextFrom(w), w, r)
+			return
+		}
+
+		f, err := fs.Open(path.Clean("/" + r.URL.Path))
+		if err != nil {
+			sendNotFound(ContextFrom(w), w, r)
+			return
+		}
+		f.Close()
+
+		h.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,59 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/kellegous/scotus/pkg/csv"
+	"github.com/kellegous/scotus/pkg/data"
+	"github.com/kellegous/scotus/pkg/logging"
+
+	"go.uber.org/zap"
+)
+
+// exportCSV serves a table of the model as CSV, e.g. /api/export/votes.csv.
+// The columns can be chosen with a comma separated columns parameter.
+func exportCSV(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+	m *data.Model,
+) {
+	name := path.Base(r.URL.Path)
+	table := strings.TrimSuffix(name, ".csv")
+	if table == name {
+		sendJSONErr(ctx, w, http.StatusNotFound, "not found")
+		return
+	}
+
+	var opts []csv.Option
+	if cols := r.FormValue("columns"); cols != "" {
+		opts = append(opts, csv.WithColumns(strings.Split(cols, ",")...))
+	}
+
+	// write to a buffer first so that errors, like an unknown column, can
+	// still be reported with an appropriate status.
+	var buf strings.Builder
+	if err := m.WriteCSV(&buf, table, opts...); errors.Is(err, data.ErrUnknownTable) {
+		sendJSONErr(ctx, w, http.StatusNotFound, "not found")
+		return
+	} else if err != nil {
+		sendJSONErr(ctx, w, http.StatusBadRequest, err.Error())
+		logging.L(ctx).Named("web").Info("unable to export",
+			zap.String("table", table),
+			zap.Error(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv;charset=utf-8")
+	w.Header().Set("Content-Disposition", "attachment; filename="+name)
+	if _, err := io.WriteString(w, buf.String()); err != nil {
+		logging.L(ctx).Named("web").Info("unable to send export",
+			zap.String("table", table),
+			zap.Error(err))
+	}
+}
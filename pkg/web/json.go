@@ -1,6 +1,7 @@
 package web
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"net/http"
@@ -16,10 +17,18 @@ func sendJSON(
 	status int,
 	data interface{},
 ) {
+	// encode to a buffer first so that a value that can't be encoded is
+	// still reported with a proper response.
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(data); err != nil {
+		sendJSONServerErr(ctx, w, err)
+		return
+	}
+
 	w.Header().Set("Content-Type", "application/json;charset=utf8")
 	w.WriteHeader(status)
-	if err := json.NewEncoder(w).Encode(data); err != nil {
-		logging.L(ctx).Panic("unable to send JSON",
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		logging.L(ctx).Named("web").Info("unable to send JSON",
 			zap.Error(err))
 	}
 }
@@ -51,6 +60,6 @@ func sendJSONServerErr(
 	err error,
 ) {
 	sendJSONErr(ctx, w, http.StatusInternalServerError, "backend error")
-	logging.L(ctx).Error("backend error",
+	logging.L(ctx).Named("web").Error("backend error",
 		zap.Error(err))
 }
@@ -0,0 +1,178 @@
+package web
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// latencyBuckets are the upper bounds, in seconds, of the request latency
+// histogram buckets.
+var latencyBuckets = []float64{
+	0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10,
+}
+
+type requestKey struct {
+	route  string
+	method string
+	code   int
+}
+
+type histogram struct {
+	counts []uint64
+	sum    float64
+	count  uint64
+}
+
+func (h *histogram) observe(v float64) {
+	for i, b := range latencyBuckets {
+		if v <= b {
+			h.counts[i]++
+		}
+	}
+	h.sum += v
+	h.count++
+}
+
+// metrics collects request metrics and writes them, along with the state
+// of the model, in the Prometheus text format.
+type metrics struct {
+	lck      sync.Mutex
+	requests map[requestKey]uint64
+	latency  map[string]*histogram
+}
+
+func newMetrics() *metrics {
+	return &metrics{
+		requests: map[requestKey]uint64{},
+		latency:  map[string]*histogram{},
+	}
+}
+
+func (m *metrics) observe(
+	route string,
+	method string,
+	code int,
+	d time.Duration,
+) {
+	m.lck.Lock()
+	defer m.lck.Unlock()
+
+	m.requests[requestKey{route, method, code}]++
+
+	h := m.latency[route]
+	if h == nil {
+		h = &histogram{counts: make([]uint64, len(latencyBuckets))}
+		m.latency[route] = h
+	}
+	h.observe(d.Seconds())
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (m *metrics) write(w io.Writer, d *Data, now time.Time) error {
+	bw := bufio.NewWriter(w)
+
+	metric := func(name, typ, help string) {
+		fmt.Fprintf(bw, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, typ)
+	}
+
+	sample := func(name string, v float64, labels ...string) {
+		bw.WriteString(name)
+		if len(labels) > 0 {
+			bw.WriteByte('{')
+			for i := 0; i < len(labels); i += 2 {
+				if i > 0 {
+					bw.WriteByte(',')
+				}
+				fmt.Fprintf(bw, `%s="%s"`, labels[i], labelEscaper.Replace(labels[i+1]))
+			}
+			bw.WriteByte('}')
+		}
+		fmt.Fprintf(bw, " %s\n", formatFloat(v))
+	}
+
+	m.lck.Lock()
+	keys := make([]requestKey, 0, len(m.requests))
+	for k := range m.requests {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := keys[i], keys[j]
+		if a.route != b.route {
+			return a.route < b.route
+		} else if a.method != b.method {
+			return a.method < b.method
+		}
+		return a.code < b.code
+	})
+
+	metric("scotus_http_requests_total", "counter", "HTTP requests by route, method and status code.")
+	for _, k := range keys {
+		sample(
+			"scotus_http_requests_total",
+			float64(m.requests[k]),
+			"route", k.route,
+			"method", k.method,
+			"code", strconv.Itoa(k.code))
+	}
+
+	metric("scotus_http_request_duration_seconds", "histogram", "HTTP request latency by route.")
+	for _, route := range sortedKeys(m.latency) {
+		h := m.latency[route]
+		for i, b := range latencyBuckets {
+			sample(
+				"scotus_http_request_duration_seconds_bucket",
+				float64(h.counts[i]),
+				"route", route,
+				"le", formatFloat(b))
+		}
+		sample("scotus_http_request_duration_seconds_bucket", float64(h.count), "route", route, "le", "+Inf")
+		sample("scotus_http_request_duration_seconds_sum", h.sum, "route", route)
+		sample("scotus_http_request_duration_seconds_count", float64(h.count), "route", route)
+	}
+	m.lck.Unlock()
+
+	ready := 0.0
+	if d != nil {
+		ready = 1
+	}
+	metric("scotus_model_ready", "gauge", "Whether the model has been loaded.")
+	sample("scotus_model_ready", ready)
+
+	if d != nil {
+		metric("scotus_model_load_duration_seconds", "gauge", "How long it took to load the model.")
+		sample("scotus_model_load_duration_seconds", d.LoadDuration.Seconds())
+
+		sizes := d.Model.Sizes()
+		metric("scotus_dataset_rows", "gauge", "Rows in each table of the model.")
+		for _, name := range sortedKeys(sizes) {
+			sample("scotus_dataset_rows", float64(sizes[name]), "dataset", name)
+		}
+
+		metric("scotus_data_age_seconds", "gauge", "Time since each source file was downloaded.")
+		for _, name := range sortedKeys(d.Sources) {
+			sample("scotus_data_age_seconds", now.Sub(d.Sources[name]).Seconds(), "source", name)
+		}
+	}
+
+	return bw.Flush()
+}
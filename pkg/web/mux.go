@@ -3,53 +3,148 @@ package web
 import (
 	"context"
 	"net/http"
+	"time"
 
 	"github.com/kellegous/scotus/pkg/logging"
 	"go.uber.org/zap"
 )
 
+// RequestIDHeader carries the id of a request. An id sent by the client, or
+// a proxy in front of the server, is used in the logs; otherwise one is
+// generated. Either way, it is echoed back in the response.
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLen limits the size of request ids accepted from clients.
+const maxRequestIDLen = 128
+
 type Mux struct {
 	*http.ServeMux
-	ctx context.Context
+	ctx     context.Context
+	metrics *metrics
 }
 
 func NewMux(ctx context.Context) *Mux {
 	return &Mux{
 		ServeMux: http.NewServeMux(),
 		ctx:      ctx,
+		metrics:  newMetrics(),
+	}
+}
+
+// Get registers a handler for the pattern that only accepts GET and HEAD
+// requests.
+func (m *Mux) Get(pattern string, fn http.HandlerFunc) {
+	m.Handle(pattern, allowMethods(fn, http.MethodGet, http.MethodHead))
+}
+
+// requestIDFrom returns the id sent with the request if it is reasonable
+// to put into logs and headers.
+func requestIDFrom(r *http.Request) string {
+	id := r.Header.Get(RequestIDHeader)
+	if len(id) > maxRequestIDLen {
+		return ""
+	}
+
+	for i := 0; i < len(id); i++ {
+		if c := id[i]; c < 0x21 || c > 0x7e {
+			return ""
+		}
 	}
+
+	return id
 }
 
 func (m *Mux) ServeHTTP(
 	w http.ResponseWriter,
 	r *http.Request,
 ) {
-	ctx, lg := logging.ForRequest(m.ctx)
+	start := time.Now()
+
+	id := requestIDFrom(r)
+	if id == "" {
+		id = logging.NewRequestID()
+	}
+	w.Header().Set(RequestIDHeader, id)
+
+	ctx, lg := logging.ForRequest(m.ctx, id)
 	rw := responseWriter{
 		ResponseWriter: w,
 		status:         http.StatusOK,
 		ctx:            ctx,
 	}
 
-	m.ServeMux.ServeHTTP(&rw, r)
+	// metrics are kept by the pattern that matched to keep the number of
+	// routes bounded.
+	_, route := m.ServeMux.Handler(r)
+
+	m.serve(&rw, r)
+	m.metrics.observe(route, r.Method, rw.status, time.Since(start))
 	lg.Info("http request",
 		zap.Int("status", rw.status),
+		zap.String("method", r.Method),
 		zap.String("uri", r.RequestURI),
 		zap.String("host", r.Host),
-		zap.String("user-agent", r.UserAgent()))
+		zap.String("remote-addr", r.RemoteAddr),
+		zap.String("user-agent", r.UserAgent()),
+		zap.Int64("bytes", rw.bytes),
+		zap.Duration("duration", time.Since(start)))
+}
+
+// serve dispatches the request, recovering from any panic in the handler
+// so that the client still gets a response.
+func (m *Mux) serve(w *responseWriter, r *http.Request) {
+	defer func() {
+		v := recover()
+		if v == nil {
+			return
+		} else if v == http.ErrAbortHandler {
+			// the handler wants the connection dropped.
+			panic(v)
+		}
+
+		logging.L(w.ctx).Error("panic serving request",
+			zap.Any("panic", v),
+			zap.Stack("stack"))
+
+		if w.wroteHeader {
+			// too late to send an error, so the client will see a truncated
+			// response.
+			return
+		}
+
+		if isAPI(r) {
+			sendJSONErr(w.ctx, w, http.StatusInternalServerError, "backend error")
+		} else {
+			sendHTMLErr(w.ctx, w, http.StatusInternalServerError, "internal server error")
+		}
+	}()
+
+	m.ServeMux.ServeHTTP(w, r)
 }
 
 type responseWriter struct {
 	http.ResponseWriter
-	ctx    context.Context
-	status int
+	ctx         context.Context
+	status      int
+	bytes       int64
+	wroteHeader bool
 }
 
 func (w *responseWriter) WriteHeader(status int) {
-	w.status = status
+	if !w.wroteHeader {
+		w.status = status
+		w.wroteHeader = true
+	}
 	w.ResponseWriter.WriteHeader(status)
 }
 
+func (w *responseWriter) Write(b []byte) (int, error) {
+	w.wroteHeader = true
+	n, err := w.ResponseWriter.Write(b)
+	w.bytes += int64(n)
+	return n, err
+}
+
 func ContextFrom(w http.ResponseWriter) context.Context {
 	if rw, ok := w.(*responseWriter); ok {
 		return rw.ctx
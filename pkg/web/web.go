@@ -2,34 +2,132 @@ package web
 
 import (
 	"context"
+	"io"
+	"net"
 	"net/http"
 	"time"
+
+	"github.com/kellegous/scotus/pkg/logging"
+
+	"go.uber.org/zap"
 )
 
+// withData wraps handlers that need the model so that they respond with
+// 503 until it is loaded.
+func withData(
+	state *State,
+	fn func(ctx context.Context, w http.ResponseWriter, r *http.Request, data *Data),
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, done := context.WithTimeout(
+			ContextFrom(w),
+			time.Minute)
+		defer done()
+
+		data := state.Data()
+		if data == nil {
+			sendErr(ctx, w, r, http.StatusServiceUnavailable, "loading")
+			return
+		}
+
+		fn(ctx, w, r, data)
+	}
+}
+
+func sendText(
+	ctx context.Context,
+	w http.ResponseWriter,
+	status int,
+	msg string,
+) {
+	w.Header().Set("Content-Type", "text/plain;charset=utf-8")
+	w.WriteHeader(status)
+	if _, err := io.WriteString(w, msg+"\n"); err != nil {
+		logging.L(ctx).Named("web").Info("unable to send text",
+			zap.Error(err))
+	}
+}
+
+// ListenAndServe listens on addr and then calls Serve.
 func ListenAndServe(
 	ctx context.Context,
 	addr string,
 	assetsDir string,
-	data *Data,
+	state *State,
+) error {
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	return Serve(ctx, l, assetsDir, state, nil)
+}
+
+// Serve serves requests on l. Until the data is set on state, /readyz
+// reports that the server is not ready and the API responds with 503. The
+// debug endpoints are only included when debug has a token.
+func Serve(
+	ctx context.Context,
+	l net.Listener,
+	assetsDir string,
+	state *State,
+	debug *Debug,
 ) error {
 	m := NewMux(ctx)
 
-	fs, err := getAssetsFS(assetsDir)
+	assets, err := getAssetsHandler(assetsDir)
 	if err != nil {
 		return err
 	}
 
-	m.Handle("/", http.FileServer(fs))
+	m.Get("/", assets.ServeHTTP)
+
+	m.Get(
+		"/healthz",
+		func(w http.ResponseWriter, r *http.Request) {
+			sendText(ContextFrom(w), w, http.StatusOK, "ok")
+		})
+
+	m.Get(
+		"/readyz",
+		func(w http.ResponseWriter, r *http.Request) {
+			if state.Data() == nil {
+				sendText(ContextFrom(w), w, http.StatusServiceUnavailable, "loading")
+				return
+			}
+			sendText(ContextFrom(w), w, http.StatusOK, "ok")
+		})
+
+	m.Get(
+		"/metrics",
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+			if err := m.metrics.write(w, state.Data(), time.Now()); err != nil {
+				logging.L(ContextFrom(w)).Named("web").Info("unable to send metrics",
+					zap.Error(err))
+			}
+		})
 
-	m.HandleFunc(
+	m.Get(
 		"/api/debug/build",
 		func(w http.ResponseWriter, r *http.Request) {
-			ctx, done := context.WithTimeout(
-				ContextFrom(w),
-				time.Minute)
-			defer done()
-			sendJSONOK(ctx, w, data.Build)
+			sendJSONOK(ContextFrom(w), w, state.Build)
 		})
 
-	return http.ListenAndServe(addr, m)
+	m.Get(
+		"/api/export/",
+		withData(state, func(ctx context.Context, w http.ResponseWriter, r *http.Request, data *Data) {
+			exportCSV(ctx, w, r, data.Model)
+		}))
+
+	m.Get(
+		"/api/model/validation",
+		withData(state, func(ctx context.Context, w http.ResponseWriter, r *http.Request, data *Data) {
+			sendJSONOK(ctx, w, data.Validation)
+		}))
+
+	if debug != nil && debug.Token != "" {
+		debug.register(m, state)
+	}
+
+	return http.Serve(l, m)
 }